@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func readBaseline(filename string) (scorecard.Baseline, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return scorecard.Baseline{}, err
+	}
+	defer f.Close()
+
+	b, err := scorecard.ReadBaseline(f)
+	if err != nil {
+		return b, fmt.Errorf("%s: %w", filename, err)
+	}
+	return b, nil
+}
+
+func writeBaseline(filename string, b scorecard.Baseline) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := b.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/score"
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/score/secrets"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// checkFlags are the flags that configure the checks, they are shared by all actions that score files
+type checkFlags struct {
+	ignoreContainerCpuLimit         *bool
+	ignoreContainerMemoryLimit      *bool
+	optionalTests                   *[]string
+	ignoreTests                     *[]string
+	disableIgnoreChecksAnnotation   *bool
+	disableOptionalChecksAnnotation *bool
+	allDefaultOptional              *bool
+	allowedCapabilities             *[]string
+	allowedHostPaths                *[]string
+	allowedHostNamespaceKinds       *[]string
+	allowedImageRegistries          *[]string
+	deniedImageRegistries           *[]string
+	mutableImageTags                *[]string
+	secretRules                     *[]string
+	disabledSecretRules             *[]string
+	secretEntropyThreshold          *float64
+	maxLimitRequestRatio            *float64
+	minContainerCPU                 *string
+	maxContainerCPU                 *string
+	minContainerMemory              *string
+	maxContainerMemory              *string
+	nodeCPU                         *string
+	nodeMemory                      *string
+	cpuUnitThreshold                *string
+	memoryUnitThreshold             *string
+	minQOSClass                     *string
+	minProbeFailureWindow           *int32
+	maxProbeFailureWindow           *int32
+	minLivenessStartupWindow        *int32
+	maxTerminationGracePeriod       *int64
+	minCronJobInterval              *time.Duration
+	maxHPAReplicas                  *int32
+	checkWeights                    *[]string
+	kubernetesVersion               *string
+}
+
+func addCheckFlags(fs *flag.FlagSet) *checkFlags {
+	return &checkFlags{
+		ignoreContainerCpuLimit:         fs.Bool("ignore-container-cpu-limit", false, "Disables the requirement of setting a container CPU limit"),
+		ignoreContainerMemoryLimit:      fs.Bool("ignore-container-memory-limit", false, "Disables the requirement of setting a container memory limit"),
+		optionalTests:                   fs.StringSlice("enable-optional-test", []string{}, "Enable an optional test, can be set multiple times"),
+		ignoreTests:                     fs.StringSlice("ignore-test", []string{}, "Disable a test, can be set multiple times"),
+		disableIgnoreChecksAnnotation:   fs.Bool("disable-ignore-checks-annotations", false, "Set to true to disable the effect of the 'kube-score/ignore' annotations"),
+		disableOptionalChecksAnnotation: fs.Bool("disable-optional-checks-annotations", false, "Set to true to disable the effect of the 'kube-score/enable' annotations"),
+		allDefaultOptional:              fs.Bool("all-default-optional", false, "Set to true to enable all tests"),
+		allowedCapabilities:             fs.StringSlice("allow-capability", []string{}, "Allow a Linux capability to be added by containers without being reported as dangerous, can be set multiple times"),
+		allowedHostPaths:                fs.StringSlice("allow-host-path", []string{}, "Allow hostPath volumes with a path under this prefix, can be set multiple times. Prefix with a kind to only allow the path for that kind, for example 'DaemonSet:/var/log'"),
+		allowedHostNamespaceKinds:       fs.StringSlice("allow-host-namespace-kind", []string{}, "Allow objects of this kind to use the host network, PID, and IPC namespaces and hostPorts, for example 'DaemonSet'. Can be set multiple times"),
+		allowedImageRegistries:          fs.StringSlice("allowed-image-registry", []string{}, "Only allow images from this registry or repository, for example 'gcr.io' or 'docker.io/library'. Can be set multiple times"),
+		deniedImageRegistries:           fs.StringSlice("denied-image-registry", []string{}, "Deny images from this registry or repository, can be set multiple times"),
+		mutableImageTags:                fs.StringSlice("mutable-image-tag", []string{}, "Treat image tags matching this pattern as mutable, for example 'main' or 'dev-*'. Can be set multiple times"),
+		secretRules:                     fs.StringSlice("secret-rule", []string{}, "Add a rule for detecting secret material on the format 'name=regexp', can be set multiple times"),
+		disabledSecretRules:             fs.StringSlice("disable-secret-rule", []string{}, "Disable a secret material detection rule, for example 'jwt' or 'high-entropy-string'. Can be set multiple times"),
+		secretEntropyThreshold:          fs.Float64("secret-entropy-threshold", 4.0, "The Shannon entropy (bits per character) above which a string is considered to be a secret"),
+		maxLimitRequestRatio:            fs.Float64("max-limit-request-ratio", 0, "The highest allowed ratio between the limit and the request of a container CPU or memory resource. Not checked if set to 0"),
+		minContainerCPU:                 fs.String("min-container-cpu", "", "The lowest allowed CPU request of a container, for example '10m'"),
+		maxContainerCPU:                 fs.String("max-container-cpu", "", "The highest allowed CPU limit of a container, for example '4'"),
+		minContainerMemory:              fs.String("min-container-memory", "", "The lowest allowed memory request of a container, for example '16Mi'"),
+		maxContainerMemory:              fs.String("max-container-memory", "", "The highest allowed memory limit of a container, for example '8Gi'"),
+		nodeCPU:                         fs.String("node-cpu", "", "The allocatable CPU of a node, pods requesting more than this are reported"),
+		nodeMemory:                      fs.String("node-memory", "", "The allocatable memory of a node, pods requesting more than this are reported"),
+		cpuUnitThreshold:                fs.String("cpu-unit-threshold", "100", "CPU quantities of at least this many whole cores are reported as likely missing the 'm' suffix. Not checked if set to 0"),
+		memoryUnitThreshold:             fs.String("memory-unit-threshold", "1Mi", "Memory quantities below this size are reported as likely missing a unit suffix. Not checked if set to 0"),
+		minQOSClass:                     fs.String("min-qos-class", "", "The lowest allowed QoS class of pods, one of Guaranteed, Burstable or BestEffort. Can be overridden per object with the kube-score/min-qos label"),
+		minProbeFailureWindow:           fs.Int32("min-probe-failure-window", 0, "The lowest allowed failureThreshold * periodSeconds of a probe, in seconds. Not checked if set to 0"),
+		maxProbeFailureWindow:           fs.Int32("max-probe-failure-window", 0, "The highest allowed failureThreshold * periodSeconds of a probe, in seconds. Not checked if set to 0"),
+		minLivenessStartupWindow:        fs.Int32("min-liveness-startup-window", 30, "The shortest time, in seconds, that a livenessProbe without a startupProbe must give the container to start. Not checked if set to 0"),
+		maxTerminationGracePeriod:       fs.Int64("max-termination-grace-period", 600, "The highest allowed terminationGracePeriodSeconds of pods targeted by a Service, in seconds"),
+		minCronJobInterval:              fs.Duration("min-cronjob-interval", 5*time.Minute, "The shortest allowed time between two runs of a CronJob"),
+		maxHPAReplicas:                  fs.Int32("max-hpa-replicas", 100, "The highest allowed maxReplicas of a HorizontalPodAutoscaler"),
+		checkWeights:                    fs.StringSlice("check-weight", []string{}, "Set the weight of a check in the score on the format 'check-id=weight', can be set multiple times. Checks have the weight 1 by default, and checks with the weight 0 do not affect the score"),
+		kubernetesVersion:               fs.String("kubernetes-version", "v1.18", "Setting the kubernetes-version will affect the checks ran against the manifests. Set this to the version of Kubernetes that you're using in production for the best results."),
+	}
+}
+
+// runConfiguration validates the flags, and returns the configuration of the checks and the IDs of the ignored checks
+func (f *checkFlags) runConfiguration() (*config.RunConfiguration, map[string]struct{}, error) {
+	if len(*f.ignoreTests) > 0 && *f.allDefaultOptional {
+		return nil, nil, usageError(errors.New("Invalid argument combination. --all-default-optional and --ignore-tests cannot be used together"))
+	}
+
+	optionalTests := *f.optionalTests
+	if *f.allDefaultOptional {
+		optionalTests = nil
+		for _, c := range score.RegisterAllChecks(parser.Empty(), nil, nil).All() {
+			if c.Optional {
+				optionalTests = append(optionalTests, c.ID)
+			}
+		}
+	}
+
+	ignoredTests := listToStructMap(f.ignoreTests)
+	enabledOptionalTests := listToStructMap(&optionalTests)
+
+	kubeVer, err := config.ParseSemver(*f.kubernetesVersion)
+	if err != nil {
+		return nil, nil, usageError(errors.New("Invalid --kubernetes-version. Use on format \"vN.NN\""))
+	}
+
+	weights, err := config.ParseCheckWeights(*f.checkWeights)
+	if err != nil {
+		return nil, nil, usageError(fmt.Errorf("Invalid --check-weight: %w", err))
+	}
+
+	resourcePolicy := config.ResourcePolicy{
+		MaxLimitRequestRatio: *f.maxLimitRequestRatio,
+	}
+
+	resourceFlags := []struct {
+		name  string
+		value string
+		dst   *resource.Quantity
+	}{
+		{"min-container-cpu", *f.minContainerCPU, &resourcePolicy.MinContainerCPU},
+		{"max-container-cpu", *f.maxContainerCPU, &resourcePolicy.MaxContainerCPU},
+		{"min-container-memory", *f.minContainerMemory, &resourcePolicy.MinContainerMemory},
+		{"max-container-memory", *f.maxContainerMemory, &resourcePolicy.MaxContainerMemory},
+		{"node-cpu", *f.nodeCPU, &resourcePolicy.NodeCPU},
+		{"node-memory", *f.nodeMemory, &resourcePolicy.NodeMemory},
+		{"cpu-unit-threshold", *f.cpuUnitThreshold, &resourcePolicy.CPUUnitThreshold},
+		{"memory-unit-threshold", *f.memoryUnitThreshold, &resourcePolicy.MemoryUnitThreshold},
+	}
+	for _, rf := range resourceFlags {
+		if rf.value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(rf.value)
+		if err != nil {
+			return nil, nil, usageError(fmt.Errorf("Invalid --%s: %w", rf.name, err))
+		}
+		*rf.dst = q
+	}
+
+	switch *f.minQOSClass {
+	case "", "Guaranteed", "Burstable", "BestEffort":
+	default:
+		return nil, nil, usageError(errors.New("Invalid --min-qos-class. Use one of Guaranteed, Burstable or BestEffort"))
+	}
+
+	runConfig := &config.RunConfiguration{
+		IgnoreContainerCpuLimitRequirement:    *f.ignoreContainerCpuLimit,
+		IgnoreContainerMemoryLimitRequirement: *f.ignoreContainerMemoryLimit,
+		EnabledOptionalTests:                  enabledOptionalTests,
+		UseIgnoreChecksAnnotation:             !*f.disableIgnoreChecksAnnotation,
+		UseOptionalChecksAnnotation:           !*f.disableOptionalChecksAnnotation,
+		KubernetesVersion:                     kubeVer,
+		AllowedCapabilities:                   listToStructMap(f.allowedCapabilities),
+		AllowedHostPaths:                      *f.allowedHostPaths,
+		AllowedHostNamespaceKinds:             listToStructMap(f.allowedHostNamespaceKinds),
+		ImagePolicy: config.ImagePolicy{
+			AllowedRegistries: *f.allowedImageRegistries,
+			DeniedRegistries:  *f.deniedImageRegistries,
+			MutableTags:       *f.mutableImageTags,
+		},
+		SecretPolicy: config.SecretPolicy{
+			Rules:            *f.secretRules,
+			DisabledRules:    listToStructMap(f.disabledSecretRules),
+			EntropyThreshold: *f.secretEntropyThreshold,
+		},
+		ResourcePolicy: resourcePolicy,
+		MinQOSClass:    *f.minQOSClass,
+		ProbePolicy: config.ProbePolicy{
+			MinFailureWindow:         *f.minProbeFailureWindow,
+			MaxFailureWindow:         *f.maxProbeFailureWindow,
+			MinLivenessStartupWindow: f.minLivenessStartupWindow,
+		},
+		ShutdownPolicy: config.ShutdownPolicy{
+			MaxTerminationGracePeriod: *f.maxTerminationGracePeriod,
+		},
+		CronJobPolicy: config.CronJobPolicy{
+			MinScheduleInterval: *f.minCronJobInterval,
+		},
+		AutoscalingPolicy: config.AutoscalingPolicy{
+			MaxReplicas: *f.maxHPAReplicas,
+		},
+		ScorePolicy: config.ScorePolicy{
+			CheckWeights: weights,
+		},
+	}
+
+	if err := secrets.Validate(runConfig.SecretPolicy); err != nil {
+		return nil, nil, usageError(fmt.Errorf("Invalid --secret-rule: %w", err))
+	}
+
+	return runConfig, ignoredTests, nil
+}
+
+// scoreReaders parses and scores the files, and returns the scorecard and the checks that were used
+func scoreReaders(files []ks.NamedReader, verboseOutput int, runConfig *config.RunConfiguration, ignoredTests map[string]struct{}) (*scorecard.Scorecard, *checks.Checks, error) {
+	p, err := parser.New(&parser.Config{
+		VerboseOutput: verboseOutput,
+	})
+	if err != nil {
+		return nil, nil, internalError(fmt.Errorf("failed to initializer parser: %w", err))
+	}
+
+	parsedFiles, err := p.ParseFiles(files)
+	if err != nil {
+		return nil, nil, parseError(fmt.Errorf("failed to parse files: %w", err))
+	}
+
+	allChecks := score.RegisterAllChecks(parsedFiles, &checks.Config{IgnoredTests: ignoredTests}, runConfig)
+
+	scoreCard, err := score.Score(parsedFiles, allChecks, runConfig)
+	if err != nil {
+		return nil, nil, scoreError(err)
+	}
+	return scoreCard, allChecks, nil
+}
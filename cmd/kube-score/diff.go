@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/renderer/human"
+	"github.com/zegl/kube-score/renderer/json_v3"
+	"github.com/zegl/kube-score/renderer/markdown"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func diffFiles(binName string, args []string) error {
+	fs := flag.NewFlagSet(binName, flag.ExitOnError)
+	against := fs.String("against", "", "Compare the arguments to the manifests in this file or directory, for example a git ref that has been exported with 'git archive'")
+	exitOneOnIntroduced := fs.Bool("exit-one-on-introduced", false, "Exit with code 1 if any findings were introduced")
+	verboseOutput := fs.CountP("verbose", "v", "Enable verbose output, can be set multiple times for increased verbosity. Objects without changes, and the unchanged findings, are included if set.")
+	printHelp := fs.Bool("help", false, "Print help")
+	outputFormat := fs.StringP("output-format", "o", "human", "Set to 'human', 'json' or 'markdown'. The json output is described by the JSON Schema in renderer/json_v3/diff_schema.json.")
+	color := fs.String("color", "auto", "If the output should be colored. Set to 'always', 'never' or 'auto'. If set to 'auto', kube-score will try to detect if the current terminal / platform supports colors. If set to 'never', kube-score will not output any colors. If set to 'always', kube-score will output colors even if the current terminal / platform does not support colors.")
+	markdownLinkBase := fs.String("markdown-link-base", "", "Link file names in the markdown output to this URL followed by the file name, for example 'https://github.com/org/repo/blob/main/'")
+	markdownMaxLength := fs.Int("markdown-max-length", 0, "The highest number of characters in the markdown output, objects that don't fit are left out. GitHub limits comments to 65536 characters. Not limited if set to 0")
+	cf := addCheckFlags(fs)
+	setDefault(fs, binName, "diff", false)
+
+	err := fs.Parse(args)
+	if err != nil {
+		return usageError(fmt.Errorf("failed to parse files: %w", err))
+	}
+
+	if *printHelp {
+		fs.Usage()
+		return nil
+	}
+
+	outputFormats := []string{"human", "json", "markdown"}
+	if !slices.Contains(outputFormats, *outputFormat) {
+		fs.Usage()
+		return usageError(fmt.Errorf("Error: --output-format must be set to one of: '%s'", strings.Join(outputFormats, "', '")))
+	}
+
+	if *color != "auto" && *color != "always" && *color != "never" {
+		fs.Usage()
+		return usageError(fmt.Errorf("Error: --color must be set to: 'auto', 'always' or 'never'"))
+	}
+
+	oldPaths, newPaths := []string{*against}, fs.Args()
+	if *against == "" {
+		if fs.NArg() != 2 {
+			return usageError(fmt.Errorf(`Error: Expected two files or directories as arguments.
+
+Usage: %s diff [--flag1 --flag2] old new
+       %s diff [--flag1 --flag2] --against old file1 file2 ...
+
+Directories are searched for .yaml, .yml and .json files.`, execName(binName), execName(binName)))
+		}
+		oldPaths, newPaths = fs.Args()[:1], fs.Args()[1:]
+	}
+	if len(newPaths) == 0 {
+		return usageError(fmt.Errorf(`Error: No files given as arguments.
+
+Usage: %s diff [--flag1 --flag2] --against old file1 file2 ...`, execName(binName)))
+	}
+
+	runConfig, ignoredTests, err := cf.runConfiguration()
+	if err != nil {
+		return err
+	}
+
+	oldCard, err := scorePaths(oldPaths, *verboseOutput, runConfig, ignoredTests)
+	if err != nil {
+		return err
+	}
+	newCard, err := scorePaths(newPaths, *verboseOutput, runConfig, ignoredTests)
+	if err != nil {
+		return err
+	}
+
+	relativeFileLocations(oldCard)
+	relativeFileLocations(newCard)
+	diff := scorecard.Diff(*oldCard, *newCard)
+
+	var r io.Reader
+	switch *outputFormat {
+	case "json":
+		r = json_v3.Diff(diff, json_v3.Options{ToolVersion: version})
+	case "markdown":
+		r = markdown.Diff(diff, markdown.Options{
+			Verbose:     *verboseOutput,
+			LinkBaseURL: *markdownLinkBase,
+			MaxLength:   *markdownMaxLength,
+		})
+	default:
+		termWidth, _, err := term.GetSize(int(os.Stdin.Fd()))
+		// Assume a width of 80 if it can't be detected
+		if err != nil {
+			termWidth = 80
+		}
+		r, err = human.Diff(diff, *verboseOutput, termWidth, useColor(*color))
+		if err != nil {
+			return internalError(err)
+		}
+	}
+
+	output, _ := io.ReadAll(r)
+	fmt.Print(string(output))
+
+	if *exitOneOnIntroduced && diff.Introduced > 0 {
+		os.Exit(exitCodeCheckFailure)
+	}
+	return nil
+}
+
+// scorePaths scores the files, and all .yaml, .yml and .json files in the directories
+func scorePaths(paths []string, verboseOutput int, runConfig *config.RunConfiguration, ignoredTests map[string]struct{}) (*scorecard.Scorecard, error) {
+	files, err := expandPaths(paths)
+	if err != nil {
+		return nil, parseError(err)
+	}
+
+	allFilePointers, err := openFiles(files)
+	if err != nil {
+		return nil, parseError(err)
+	}
+
+	scoreCard, _, err := scoreReaders(allFilePointers, verboseOutput, runConfig, ignoredTests)
+	return scoreCard, err
+}
+
+// expandPaths replaces the directories with the manifests in them, in lexical order
+func expandPaths(paths []string) ([]string, error) {
+	var res []string
+	for _, path := range paths {
+		if path == "-" {
+			res = append(res, path)
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			res = append(res, path)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			switch filepath.Ext(p) {
+			case ".yaml", ".yml", ".json":
+				if d.Type().IsRegular() {
+					res = append(res, p)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
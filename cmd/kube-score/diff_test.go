@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.yaml", "a.yml", "sub/c.json", "README.md"} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+
+	paths, err := expandPaths([]string{dir, "-", filepath.Join(dir, "README.md")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.yml"),
+		filepath.Join(dir, "b.yaml"),
+		filepath.Join(dir, "sub/c.json"),
+		"-",
+		filepath.Join(dir, "README.md"),
+	}, paths)
+
+	_, err = expandPaths([]string{filepath.Join(dir, "missing")})
+	assert.Error(t, err)
+}
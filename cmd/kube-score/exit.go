@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// The exit codes of kube-score, so that CI can tell a broken manifest apart from a policy violation
+const (
+	exitCodeOK = 0
+	// exitCodeCheckFailure is used when the exit policy is violated
+	exitCodeCheckFailure = 1
+	// exitCodeUsage is used for invalid flags and arguments, the same exit code as pflag uses
+	exitCodeUsage = 2
+	// exitCodeParse is used when the input files can't be read or parsed, or when an object has an invalid value
+	exitCodeParse = 3
+	// exitCodeInternal is used for all other errors
+	exitCodeInternal = 4
+)
+
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e exitError) Unwrap() error {
+	return e.err
+}
+
+func usageError(err error) error {
+	return exitError{code: exitCodeUsage, err: err}
+}
+
+func parseError(err error) error {
+	return exitError{code: exitCodeParse, err: err}
+}
+
+func internalError(err error) error {
+	return exitError{code: exitCodeInternal, err: err}
+}
+
+// scoreError wraps an error from score.Score. Checks fail on invalid values in the objects, which is a broken manifest
+// and not an internal error.
+func scoreError(err error) error {
+	var objectErr *score.ObjectError
+	if errors.As(err, &objectErr) {
+		return parseError(err)
+	}
+	return internalError(err)
+}
+
+// validateCheckIDs returns an error if any of the IDs is not the ID of a check
+func validateCheckIDs(ids []string, all []ks.Check) error {
+	known := make(map[string]struct{}, len(all))
+	for _, c := range all {
+		known[c.ID] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := known[id]; !ok {
+			return fmt.Errorf("unknown check %q", id)
+		}
+	}
+	return nil
+}
+
+// exitCode returns the exit code for the error, errors that are not wrapped are internal errors
+func exitCode(err error) int {
+	var e exitError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return exitCodeInternal
+}
+
+// exitPolicy decides if the result of a run is a failure
+type exitPolicy struct {
+	// maxCriticals and maxWarnings are the highest allowed number of failed checks, not limited if negative
+	maxCriticals int
+	maxWarnings  int
+
+	// minScore is the lowest allowed score
+	minScore int
+
+	// checks and namespaces limit the policy to the checks with these IDs, and objects in these namespaces. All checks
+	// and namespaces are used if empty.
+	checks     map[string]struct{}
+	namespaces map[string]struct{}
+}
+
+// violations returns a description of each violation of the policy
+func (p exitPolicy) violations(scoreCard *scorecard.Scorecard) []string {
+	filtered := scorecard.New()
+	for key, o := range *scoreCard {
+		if _, ok := p.namespaces[o.ObjectMeta.Namespace]; len(p.namespaces) > 0 && !ok {
+			continue
+		}
+
+		obj := *o
+		obj.Checks = nil
+		for _, c := range o.Checks {
+			if _, ok := p.checks[c.Check.ID]; len(p.checks) > 0 && !ok {
+				continue
+			}
+			obj.Checks = append(obj.Checks, c)
+		}
+		filtered[key] = &obj
+	}
+
+	summary := filtered.Summary().Overall
+
+	var res []string
+	if p.maxCriticals >= 0 && summary.Critical > p.maxCriticals {
+		res = append(res, fmt.Sprintf("%d checks are critical, the maximum is %d", summary.Critical, p.maxCriticals))
+	}
+	if p.maxWarnings >= 0 && summary.Warning > p.maxWarnings {
+		res = append(res, fmt.Sprintf("%d checks have warnings, the maximum is %d", summary.Warning, p.maxWarnings))
+	}
+	if summary.Score < p.minScore {
+		res = append(res, fmt.Sprintf("the score is %d, the minimum is %d", summary.Score, p.minScore))
+	}
+	return res
+}
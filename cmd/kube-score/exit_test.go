@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/score"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, exitCodeUsage, exitCode(usageError(errors.New("invalid flag"))))
+	assert.Equal(t, exitCodeParse, exitCode(parseError(errors.New("invalid yaml"))))
+	assert.Equal(t, exitCodeInternal, exitCode(fmt.Errorf("wrapped: %w", internalError(errors.New("failed")))))
+	assert.Equal(t, exitCodeInternal, exitCode(errors.New("failed")))
+	assert.Equal(t, "failed", internalError(errors.New("failed")).Error())
+}
+
+func TestExitCodeInvalidObjectValue(t *testing.T) {
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  selector:
+    matchExpressions:
+    - key: app
+      operator: Bogus
+      values: [app]
+  template:
+    metadata:
+      labels:
+        app: app
+    spec:
+      containers:
+      - name: app
+        image: foo:bar
+`
+	p, err := parser.New(nil)
+	assert.Nil(t, err)
+	parsed, err := p.ParseFiles([]ks.NamedReader{namedReader{Reader: strings.NewReader(manifest), name: "app.yaml"}})
+	assert.Nil(t, err)
+
+	cnf := &config.RunConfiguration{}
+	_, err = score.Score(parsed, score.RegisterAllChecks(parsed, nil, cnf), cnf)
+	var objectErr *score.ObjectError
+	assert.True(t, errors.As(err, &objectErr))
+	assert.Equal(t, exitCodeParse, exitCode(scoreError(err)))
+	assert.Equal(t, exitCodeInternal, exitCode(scoreError(errors.New("failed"))))
+}
+
+func TestValidateCheckIDs(t *testing.T) {
+	all := score.RegisterAllChecks(parser.Empty(), nil, nil).All()
+	assert.Nil(t, validateCheckIDs(nil, all))
+	assert.Nil(t, validateCheckIDs([]string{"container-resources", "pod-probes"}, all))
+	assert.EqualError(t, validateCheckIDs([]string{"container-resources", "no-such-check"}, all), `unknown check "no-such-check"`)
+}
+
+func exitPolicyTestCard() *scorecard.Scorecard {
+	s := scorecard.New()
+	add := func(namespace, name string, checks map[string]scorecard.Grade) {
+		o := s.NewObject(metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}, metav1.ObjectMeta{Namespace: namespace, Name: name}, nil)
+		for id, grade := range checks {
+			o.Checks = append(o.Checks, scorecard.TestScore{Check: ks.Check{ID: id}, Grade: grade})
+		}
+	}
+	add("prod", "a", map[string]scorecard.Grade{"container-resources": scorecard.GradeAllOK, "pod-probes": scorecard.GradeWarning})
+	add("dev", "b", map[string]scorecard.Grade{"container-resources": scorecard.GradeCritical})
+	return &s
+}
+
+func TestExitPolicy(t *testing.T) {
+	sc := exitPolicyTestCard()
+
+	tests := []struct {
+		name     string
+		policy   exitPolicy
+		expected []string
+	}{
+		{
+			name:     "default",
+			policy:   exitPolicy{maxCriticals: 0, maxWarnings: -1},
+			expected: []string{"1 checks are critical, the maximum is 0"},
+		},
+		{
+			name:   "unlimited",
+			policy: exitPolicy{maxCriticals: -1, maxWarnings: -1},
+		},
+		{
+			name:   "max criticals",
+			policy: exitPolicy{maxCriticals: 1, maxWarnings: -1},
+		},
+		{
+			name:     "max warnings",
+			policy:   exitPolicy{maxCriticals: -1, maxWarnings: 0},
+			expected: []string{"1 checks have warnings, the maximum is 0"},
+		},
+		{
+			// (1 + 4/9 + 0) / 3
+			name:     "min score",
+			policy:   exitPolicy{maxCriticals: -1, maxWarnings: -1, minScore: 50},
+			expected: []string{"the score is 48, the minimum is 50"},
+		},
+		{
+			name:   "namespace",
+			policy: exitPolicy{maxCriticals: 0, maxWarnings: -1, namespaces: map[string]struct{}{"prod": {}}},
+		},
+		{
+			name:     "namespace warnings",
+			policy:   exitPolicy{maxCriticals: 0, maxWarnings: 0, namespaces: map[string]struct{}{"prod": {}}},
+			expected: []string{"1 checks have warnings, the maximum is 0"},
+		},
+		{
+			name:   "check",
+			policy: exitPolicy{maxCriticals: 0, maxWarnings: 0, checks: map[string]struct{}{"pod-probes": {}, "container-ports-check": {}}, namespaces: map[string]struct{}{"dev": {}}},
+		},
+		{
+			name:     "check score",
+			policy:   exitPolicy{maxCriticals: 0, maxWarnings: -1, minScore: 100, checks: map[string]struct{}{"container-resources": {}}},
+			expected: []string{"1 checks are critical, the maximum is 0", "the score is 50, the minimum is 100"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.violations(sc))
+		})
+	}
+
+	// The scorecard is not modified
+	assert.Len(t, (*sc)["Deployment/apps/v1/prod/a"].Checks, 2)
+}
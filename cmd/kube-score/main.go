@@ -4,24 +4,29 @@ import (
 	"bytes"
 	"encoding/csv"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/mattn/go-isatty"
 	flag "github.com/spf13/pflag"
-	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/renderer/checkstyle"
 	"github.com/zegl/kube-score/renderer/ci"
+	"github.com/zegl/kube-score/renderer/github"
+	"github.com/zegl/kube-score/renderer/gitlab"
+	"github.com/zegl/kube-score/renderer/html"
 	"github.com/zegl/kube-score/renderer/human"
 	"github.com/zegl/kube-score/renderer/json_v2"
+	"github.com/zegl/kube-score/renderer/json_v3"
 	"github.com/zegl/kube-score/renderer/junit"
+	"github.com/zegl/kube-score/renderer/markdown"
 	"github.com/zegl/kube-score/renderer/sarif"
 	"github.com/zegl/kube-score/score"
-	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/scorecard"
 	"golang.org/x/term"
 )
@@ -36,7 +41,14 @@ func main() {
 		"score": func(helpName string, args []string) {
 			if err := scoreFiles(helpName, args); err != nil {
 				_, _ = fmt.Fprintf(os.Stderr, "Failed to score files: %v\n", err)
-				os.Exit(1)
+				os.Exit(exitCode(err))
+			}
+		},
+
+		"diff": func(helpName string, args []string) {
+			if err := diffFiles(helpName, args); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Failed to diff files: %v\n", err)
+				os.Exit(exitCode(err))
 			}
 		},
 
@@ -47,6 +59,13 @@ func main() {
 			}
 		},
 
+		"netpol": func(helpName string, args []string) {
+			if err := networkPolicySummary(helpName, args); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Failed to summarize NetworkPolicies: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+		},
+
 		"version": func(helpName string, args []string) {
 			cmdVersion()
 		},
@@ -78,7 +97,9 @@ func setDefault(fs *flag.FlagSet, binName, actionName string, displayForMoreInfo
 
 Actions:
 	score	Checks all files in the input, and gives them a score and recommendations
+	diff	Compares the findings of two versions of the files, and shows which were introduced and resolved
 	list	Prints a CSV list of all available score checks
+	netpol	Prints a summary of which workloads are exposed by the NetworkPolicies in each namespace
 	version	Print the version of kube-score
 	help	Print this message`+"\n\n", binName, binName)
 
@@ -100,25 +121,27 @@ Actions:
 
 func scoreFiles(binName string, args []string) error {
 	fs := flag.NewFlagSet(binName, flag.ExitOnError)
-	exitOneOnWarning := fs.Bool("exit-one-on-warning", false, "Exit with code 1 in case of warnings")
-	ignoreContainerCpuLimit := fs.Bool("ignore-container-cpu-limit", false, "Disables the requirement of setting a container CPU limit")
-	ignoreContainerMemoryLimit := fs.Bool("ignore-container-memory-limit", false, "Disables the requirement of setting a container memory limit")
+	exitOneOnWarning := fs.Bool("exit-one-on-warning", false, "Exit with code 1 in case of warnings, the same as --max-warnings=0")
+	maxCriticals := fs.Int("max-criticals", 0, "Exit with code 1 if more checks than this are critical. Not limited if set to -1")
+	maxWarnings := fs.Int("max-warnings", -1, "Exit with code 1 if more checks than this have warnings. Not limited if set to -1")
+	minScore := fs.Int("min-score", 0, "Exit with code 1 if the score is lower than this, from 0 to 100")
+	failOnChecks := fs.StringSlice("fail-on-check", []string{}, "Only use the results of this check to decide the exit code, can be set multiple times")
+	failOnNamespaces := fs.StringSlice("fail-on-namespace", []string{}, "Only use the objects in this namespace to decide the exit code, can be set multiple times")
+	baselineFile := fs.String("baseline", "", "Hide the findings that are in this baseline file, and report the findings of the baseline that have been fixed")
+	writeBaselineFile := fs.String("write-baseline", "", "Write all current findings to this baseline file, and exit")
 	verboseOutput := fs.CountP("verbose", "v", "Enable verbose output, can be set multiple times for increased verbosity.")
 	printHelp := fs.Bool("help", false, "Print help")
-	outputFormat := fs.StringP("output-format", "o", "human", "Set to 'human', 'json', 'ci' or 'sarif'. If set to ci, kube-score will output the program in a format that is easier to parse by other programs. Sarif output allows for easier integration with CI platforms.")
-	outputVersion := fs.String("output-version", "", "Changes the version of the --output-format. The 'json' format has version 'v2' (default) and 'v1' (deprecated, will be removed in v1.7.0). The 'human' and 'ci' formats has only version 'v1' (default). If not explicitly set, the default version for that particular output format will be used.")
+	outputFormat := fs.StringP("output-format", "o", "human", "Set to 'human', 'json', 'ci', 'sarif', 'junit', 'markdown', 'html', 'github', 'gitlab-codequality' or 'checkstyle'. If set to ci, kube-score will output the program in a format that is easier to parse by other programs. Sarif output allows for easier integration with CI platforms. Markdown output is intended for pull request comments. Html output is a self-contained report page. Github output creates annotations in GitHub Actions, and writes a job summary if $GITHUB_STEP_SUMMARY is set. Gitlab-codequality and checkstyle output are reports for GitLab merge requests, and tools such as Jenkins and SonarQube.")
+	outputVersion := fs.String("output-version", "", "Changes the version of the --output-format. The 'json' format has version 'v2' (default), 'v3' and 'v1' (deprecated, will be removed in v1.7.0). The 'v3' version has a stable order, and is described by the JSON Schema in renderer/json_v3/schema.json. The 'human' and 'ci' formats has only version 'v1' (default). If not explicitly set, the default version for that particular output format will be used.")
 	color := fs.String("color", "auto", "If the output should be colored. Set to 'always', 'never' or 'auto'. If set to 'auto', kube-score will try to detect if the current terminal / platform supports colors. If set to 'never', kube-score will not output any colors. If set to 'always', kube-score will output colors even if the current terminal / platform does not support colors.")
-	optionalTests := fs.StringSlice("enable-optional-test", []string{}, "Enable an optional test, can be set multiple times")
-	ignoreTests := fs.StringSlice("ignore-test", []string{}, "Disable a test, can be set multiple times")
-	disableIgnoreChecksAnnotation := fs.Bool("disable-ignore-checks-annotations", false, "Set to true to disable the effect of the 'kube-score/ignore' annotations")
-	disableOptionalChecksAnnotation := fs.Bool("disable-optional-checks-annotations", false, "Set to true to disable the effect of the 'kube-score/enable' annotations")
-	allDefaultOptional := fs.Bool("all-default-optional", false, "Set to true to enable all tests")
-	kubernetesVersion := fs.String("kubernetes-version", "v1.18", "Setting the kubernetes-version will affect the checks ran against the manifests. Set this to the version of Kubernetes that you're using in production for the best results.")
+	markdownLinkBase := fs.String("markdown-link-base", "", "Link file names in the markdown output to this URL followed by the file name, for example 'https://github.com/org/repo/blob/main/'")
+	markdownMaxLength := fs.Int("markdown-max-length", 0, "The highest number of characters in the markdown output, objects that don't fit are left out. GitHub limits comments to 65536 characters. Not limited if set to 0")
+	cf := addCheckFlags(fs)
 	setDefault(fs, binName, "score", false)
 
 	err := fs.Parse(args)
 	if err != nil {
-		return fmt.Errorf("failed to parse files: %w", err)
+		return usageError(fmt.Errorf("failed to parse files: %w", err))
 	}
 
 	if *printHelp {
@@ -126,9 +149,10 @@ func scoreFiles(binName string, args []string) error {
 		return nil
 	}
 
-	if *outputFormat != "human" && *outputFormat != "ci" && *outputFormat != "json" && *outputFormat != "sarif" && *outputFormat != "junit" {
+	outputFormats := []string{"human", "json", "sarif", "junit", "markdown", "html", "github", "gitlab-codequality", "checkstyle", "ci"}
+	if !slices.Contains(outputFormats, *outputFormat) {
 		fs.Usage()
-		return fmt.Errorf("Error: --output-format must be set to: 'human', 'json', 'sarif', 'junit' or 'ci'")
+		return usageError(fmt.Errorf("Error: --output-format must be set to one of: '%s'", strings.Join(outputFormats, "', '")))
 	}
 
 	acceptedColors := map[string]bool{
@@ -138,111 +162,91 @@ func scoreFiles(binName string, args []string) error {
 	}
 	if !acceptedColors[*color] {
 		fs.Usage()
-		return fmt.Errorf("Error: --color must be set to: 'auto', 'always' or 'never'")
+		return usageError(fmt.Errorf("Error: --color must be set to: 'auto', 'always' or 'never'"))
 	}
 
 	filesToRead := fs.Args()
 	if len(filesToRead) == 0 {
-		return fmt.Errorf(`Error: No files given as arguments.
+		return usageError(fmt.Errorf(`Error: No files given as arguments.
 
 Usage: %s score [--flag1 --flag2] file1 file2 ...
 
-Use "-" as filename to read from STDIN.`, execName(binName))
+Use "-" as filename to read from STDIN.`, execName(binName)))
 	}
 
-	var allFilePointers []ks.NamedReader
-
-	for _, file := range filesToRead {
-		var fp io.Reader
-		var filename string
-
-		if file == "-" {
-			fp = os.Stdin
-			filename = "STDIN"
-		} else {
-			var err error
-			fp, err = os.Open(file)
-			if err != nil {
-				return err
-			}
-			filename, _ = filepath.Abs(file)
-		}
-		allFilePointers = append(allFilePointers, namedReader{Reader: fp, name: filename})
+	allFilePointers, err := openFiles(filesToRead)
+	if err != nil {
+		return parseError(err)
 	}
 
-	if len(*ignoreTests) > 0 && *allDefaultOptional {
-		return errors.New("Invalid argument combination. --all-default-optional and --ignore-tests cannot be used together")
+	runConfig, ignoredTests, err := cf.runConfiguration()
+	if err != nil {
+		return err
 	}
 
-	if *allDefaultOptional {
-		var addOptionalChecks []string
-		for _, c := range score.RegisterAllChecks(parser.Empty(), nil, nil).All() {
-			if c.Optional {
-				addOptionalChecks = append(addOptionalChecks, c.ID)
-			}
-		}
-		optionalTests = &addOptionalChecks
+	if err := validateCheckIDs(*failOnChecks, score.RegisterAllChecks(parser.Empty(), nil, nil).All()); err != nil {
+		return usageError(fmt.Errorf("Invalid --fail-on-check: %w", err))
 	}
 
-	ignoredTests := listToStructMap(ignoreTests)
-	enabledOptionalTests := listToStructMap(optionalTests)
-
-	kubeVer, err := config.ParseSemver(*kubernetesVersion)
+	scoreCard, checks, err := scoreReaders(allFilePointers, *verboseOutput, runConfig, ignoredTests)
 	if err != nil {
-		return errors.New("Invalid --kubernetes-version. Use on format \"vN.NN\"")
+		return err
 	}
 
-	runConfig := &config.RunConfiguration{
-		IgnoreContainerCpuLimitRequirement:    *ignoreContainerCpuLimit,
-		IgnoreContainerMemoryLimitRequirement: *ignoreContainerMemoryLimit,
-		EnabledOptionalTests:                  enabledOptionalTests,
-		UseIgnoreChecksAnnotation:             !*disableIgnoreChecksAnnotation,
-		UseOptionalChecksAnnotation:           !*disableOptionalChecksAnnotation,
-		KubernetesVersion:                     kubeVer,
+	if *writeBaselineFile != "" {
+		b := scoreCard.Baseline()
+		if err := writeBaseline(*writeBaselineFile, b); err != nil {
+			return internalError(fmt.Errorf("failed to write baseline: %w", err))
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "kube-score: wrote %d findings to %s\n", len(b.Findings), *writeBaselineFile)
+		return nil
 	}
 
-	p, err := parser.New(&parser.Config{
-		VerboseOutput: *verboseOutput,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to initializer parser: %w", err)
+	var fixedFindings []scorecard.BaselineFinding
+	if *baselineFile != "" {
+		b, err := readBaseline(*baselineFile)
+		if err != nil {
+			return parseError(err)
+		}
+		fixedFindings = scoreCard.ApplyBaseline(b)
 	}
 
-	parsedFiles, err := p.ParseFiles(allFilePointers)
-	if err != nil {
-		return fmt.Errorf("failed to parse files: %w", err)
+	policy := exitPolicy{
+		maxCriticals: *maxCriticals,
+		maxWarnings:  *maxWarnings,
+		minScore:     *minScore,
+		checks:       listToStructMap(failOnChecks),
+		namespaces:   listToStructMap(failOnNamespaces),
 	}
-
-	checks := score.RegisterAllChecks(parsedFiles, &checks.Config{IgnoredTests: ignoredTests}, runConfig)
-
-	scoreCard, err := score.Score(parsedFiles, checks, runConfig)
-	if err != nil {
-		return err
+	if *exitOneOnWarning {
+		policy.maxWarnings = 0
 	}
 
-	var exitCode int
-	switch {
-	case scoreCard.AnyBelowOrEqualToGrade(scorecard.GradeCritical):
-		exitCode = 1
-	case *exitOneOnWarning && scoreCard.AnyBelowOrEqualToGrade(scorecard.GradeWarning):
-		exitCode = 1
-	default:
-		exitCode = 0
+	violations := policy.violations(scoreCard)
+	exitCode := exitCodeOK
+	if len(violations) > 0 {
+		exitCode = exitCodeCheckFailure
 	}
 
 	var r io.Reader
 
-	version := getOutputVersion(*outputVersion, *outputFormat)
+	formatVersion := getOutputVersion(*outputVersion, *outputFormat)
 
 	switch {
-	case *outputFormat == "json" && version == "v1":
+	case *outputFormat == "json" && formatVersion == "v1":
 		d, _ := json.MarshalIndent(scoreCard, "", "    ")
 		w := bytes.NewBufferString("")
 		w.WriteString(string(d))
 		r = w
-	case *outputFormat == "json" && version == "v2":
+	case *outputFormat == "json" && formatVersion == "v2":
 		r = json_v2.Output(scoreCard)
-	case *outputFormat == "human" && version == "v1":
+	case *outputFormat == "json" && formatVersion == "v3":
+		r = json_v3.Output(scoreCard, json_v3.Options{
+			ToolVersion:  version,
+			Config:       runConfig,
+			IgnoredTests: ignoredTests,
+		})
+	case *outputFormat == "human" && formatVersion == "v1":
 		termWidth, _, err := term.GetSize(int(os.Stdin.Fd()))
 		// Assume a width of 80 if it can't be detected
 		if err != nil {
@@ -250,20 +254,54 @@ Use "-" as filename to read from STDIN.`, execName(binName))
 		}
 		r, err = human.Human(scoreCard, *verboseOutput, termWidth, useColor(*color))
 		if err != nil {
-			return err
+			return internalError(err)
 		}
-	case *outputFormat == "ci" && version == "v1":
+	case *outputFormat == "ci" && formatVersion == "v1":
 		r = ci.CI(scoreCard)
 	case *outputFormat == "sarif":
+		relativeFileLocations(scoreCard)
 		r = sarif.Output(scoreCard)
 	case *outputFormat == "junit":
 		r = junit.JUnit(scoreCard)
+	case *outputFormat == "markdown":
+		relativeFileLocations(scoreCard)
+		r = markdown.Markdown(scoreCard, markdown.Options{
+			Verbose:     *verboseOutput,
+			LinkBaseURL: *markdownLinkBase,
+			MaxLength:   *markdownMaxLength,
+		})
+	case *outputFormat == "github":
+		relativeFileLocations(scoreCard)
+		r = github.Output(scoreCard)
+		if summaryFile, ok := os.LookupEnv("GITHUB_STEP_SUMMARY"); ok && summaryFile != "" {
+			if err := writeStepSummary(summaryFile, github.StepSummary(scoreCard, *verboseOutput)); err != nil {
+				return internalError(err)
+			}
+		}
+	case *outputFormat == "gitlab-codequality":
+		relativeFileLocations(scoreCard)
+		r = gitlab.CodeQuality(scoreCard)
+	case *outputFormat == "checkstyle":
+		r = checkstyle.Checkstyle(scoreCard)
+	case *outputFormat == "html":
+		r, err = html.HTML(scoreCard, checks.All())
+		if err != nil {
+			return internalError(err)
+		}
 	default:
-		return fmt.Errorf("error: Unknown --output-format or --output-version")
+		return usageError(fmt.Errorf("error: Unknown --output-format or --output-version"))
 	}
 
 	output, _ := io.ReadAll(r)
 	fmt.Print(string(output))
+
+	for _, f := range fixedFindings {
+		_, _ = fmt.Fprintf(os.Stderr, "kube-score: %s is fixed, and can be removed from the baseline\n", f)
+	}
+	for _, v := range violations {
+		_, _ = fmt.Fprintf(os.Stderr, "kube-score: %s\n", v)
+	}
+
 	os.Exit(exitCode)
 	return nil
 }
@@ -313,6 +351,31 @@ func listChecks(binName string, args []string) error {
 	return nil
 }
 
+// openFiles opens all files for reading, "-" reads from STDIN
+func openFiles(filesToRead []string) ([]ks.NamedReader, error) {
+	var allFilePointers []ks.NamedReader
+
+	for _, file := range filesToRead {
+		var fp io.Reader
+		var filename string
+
+		if file == "-" {
+			fp = os.Stdin
+			filename = "STDIN"
+		} else {
+			var err error
+			fp, err = os.Open(file)
+			if err != nil {
+				return nil, err
+			}
+			filename, _ = filepath.Abs(file)
+		}
+		allFilePointers = append(allFilePointers, namedReader{Reader: fp, name: filename})
+	}
+
+	return allFilePointers, nil
+}
+
 func listToStructMap(items *[]string) map[string]struct{} {
 	structMap := make(map[string]struct{})
 	for _, testID := range *items {
@@ -330,6 +393,36 @@ func (n namedReader) Name() string {
 	return n.name
 }
 
+// relativeFileLocations makes the file names relative to the working directory, so that they match the paths in the
+// repository when kube-score runs in CI
+func relativeFileLocations(scoreCard *scorecard.Scorecard) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	for _, o := range *scoreCard {
+		if !filepath.IsAbs(o.FileLocation.Name) {
+			continue
+		}
+		if rel, err := filepath.Rel(wd, o.FileLocation.Name); err == nil && filepath.IsLocal(rel) {
+			o.FileLocation.Name = filepath.ToSlash(rel)
+		}
+	}
+}
+
+// writeStepSummary appends the summary to the file, other steps in the same job may already have written to it
+func writeStepSummary(filename string, summary io.Reader) error {
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open job summary: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, summary); err != nil {
+		return fmt.Errorf("failed to write job summary: %w", err)
+	}
+	return nil
+}
+
 func useColor(colorArg string) bool {
 	// Respect user preference
 	switch colorArg {
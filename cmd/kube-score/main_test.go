@@ -0,0 +1,42 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/renderer/markdown"
+	"github.com/zegl/kube-score/scorecard"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMarkdownLinkIsRelativeToWorkingDirectory(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+
+	card := scorecard.New()
+	o := card.NewObject(v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}, v1.ObjectMeta{Name: "app"}, nil)
+	o.FileLocation = domain.FileLocation{Name: filepath.Join(wd, "deploy", "app.yaml"), Line: 3}
+
+	relativeFileLocations(&card)
+	assert.Equal(t, "deploy/app.yaml", o.FileLocation.Name)
+
+	r := markdown.Markdown(&card, markdown.Options{LinkBaseURL: "https://github.com/org/repo/blob/main/"})
+	all, err := io.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Contains(t, string(all), "(https://github.com/org/repo/blob/main/deploy/app.yaml#L3)")
+	assert.NotContains(t, string(all), wd)
+}
+
+func TestRelativeFileLocationsOutsideWorkingDirectory(t *testing.T) {
+	card := scorecard.New()
+	o := card.NewObject(v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}, v1.ObjectMeta{Name: "app"}, nil)
+	o.FileLocation = domain.FileLocation{Name: "/somewhere/else/app.yaml"}
+
+	relativeFileLocations(&card)
+	assert.Equal(t, "/somewhere/else/app.yaml", o.FileLocation.Name)
+}
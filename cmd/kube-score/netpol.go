@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/score/networkpolicy"
+)
+
+func networkPolicySummary(binName string, args []string) error {
+	fs := flag.NewFlagSet(binName, flag.ExitOnError)
+	printHelp := fs.Bool("help", false, "Print help")
+	verboseOutput := fs.CountP("verbose", "v", "Enable verbose output, can be set multiple times for increased verbosity.")
+	setDefault(fs, binName, "netpol", false)
+	err := fs.Parse(args)
+	if err != nil {
+		return usageError(fmt.Errorf("failed to parse files: %w", err))
+	}
+
+	if *printHelp {
+		fs.Usage()
+		return nil
+	}
+
+	filesToRead := fs.Args()
+	if len(filesToRead) == 0 {
+		return usageError(fmt.Errorf(`Error: No files given as arguments.
+
+Usage: %s netpol [--flag1 --flag2] file1 file2 ...
+
+Use "-" as filename to read from STDIN.`, execName(binName)))
+	}
+
+	allFilePointers, err := openFiles(filesToRead)
+	if err != nil {
+		return parseError(err)
+	}
+
+	p, err := parser.New(&parser.Config{
+		VerboseOutput: *verboseOutput,
+	})
+	if err != nil {
+		return internalError(fmt.Errorf("failed to initializer parser: %w", err))
+	}
+
+	parsedFiles, err := p.ParseFiles(allFilePointers)
+	if err != nil {
+		return parseError(fmt.Errorf("failed to parse files: %w", err))
+	}
+
+	writeNetworkPolicySummary(os.Stdout, networkpolicy.Summarize(parsedFiles, parsedFiles, parsedFiles))
+	return nil
+}
+
+func writeNetworkPolicySummary(w io.Writer, summaries []networkpolicy.NamespaceSummary) {
+	direction := func(name string, isolated bool, exposures []networkpolicy.Exposure, preposition string) {
+		switch {
+		case !isolated:
+			_, _ = fmt.Fprintf(w, "        %s: not isolated, all traffic is allowed\n", name)
+		case len(exposures) == 0:
+			_, _ = fmt.Fprintf(w, "        %s: isolated, all traffic is denied\n", name)
+		default:
+			_, _ = fmt.Fprintf(w, "        %s: isolated\n", name)
+			for _, e := range exposures {
+				_, _ = fmt.Fprintf(w, "            %s %s %s (%s)\n", e.Ports, preposition, e.Peers, e.Policy)
+			}
+		}
+	}
+
+	for i, s := range summaries {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+
+		namespace := s.Namespace
+		if namespace == "" {
+			namespace = "(not set)"
+		}
+		_, _ = fmt.Fprintf(w, "Namespace %s\n", namespace)
+
+		var defaultDeny []string
+		if s.DefaultDenyIngress {
+			defaultDeny = append(defaultDeny, "ingress")
+		}
+		if s.DefaultDenyEgress {
+			defaultDeny = append(defaultDeny, "egress")
+		}
+		if len(defaultDeny) == 0 {
+			defaultDeny = append(defaultDeny, "none")
+		}
+		_, _ = fmt.Fprintf(w, "    Default deny: %s\n", strings.Join(defaultDeny, ", "))
+
+		for _, workload := range s.Workloads {
+			_, _ = fmt.Fprintf(w, "    %s %s\n", workload.Kind, workload.Name)
+			direction("Ingress", workload.IngressIsolated, workload.Ingress, "from")
+			direction("Egress", workload.EgressIsolated, workload.Egress, "to")
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/score/networkpolicy"
+)
+
+func TestWriteNetworkPolicySummary(t *testing.T) {
+	var buf bytes.Buffer
+	writeNetworkPolicySummary(&buf, []networkpolicy.NamespaceSummary{
+		{
+			Namespace:          "prod",
+			DefaultDenyIngress: true,
+			Workloads: []networkpolicy.WorkloadSummary{
+				{
+					Kind:            "Deployment",
+					Name:            "api",
+					IngressIsolated: true,
+					Ingress: []networkpolicy.Exposure{
+						{Policy: "api", Ports: "8080/TCP", Peers: "pods app=frontend"},
+					},
+				},
+				{Kind: "Deployment", Name: "worker", IngressIsolated: true},
+			},
+		},
+		{
+			Workloads: []networkpolicy.WorkloadSummary{{Kind: "Pod", Name: "debug"}},
+		},
+	})
+
+	assert.Equal(t, `Namespace prod
+    Default deny: ingress
+    Deployment api
+        Ingress: isolated
+            8080/TCP from pods app=frontend (api)
+        Egress: not isolated, all traffic is allowed
+    Deployment worker
+        Ingress: isolated, all traffic is denied
+        Egress: not isolated, all traffic is allowed
+
+Namespace (not set)
+    Default deny: none
+    Pod debug
+        Ingress: not isolated, all traffic is allowed
+        Egress: not isolated, all traffic is allowed
+`, buf.String())
+}
@@ -3,8 +3,12 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 type RunConfiguration struct {
@@ -14,6 +18,112 @@ type RunConfiguration struct {
 	UseIgnoreChecksAnnotation             bool
 	UseOptionalChecksAnnotation           bool
 	KubernetesVersion                     Semver
+	AllowedCapabilities                   map[string]struct{}
+	AllowedHostPaths                      []string
+	AllowedHostNamespaceKinds             map[string]struct{}
+	ImagePolicy                           ImagePolicy
+	SecretPolicy                          SecretPolicy
+	ResourcePolicy                        ResourcePolicy
+	MinQOSClass                           string
+	ProbePolicy                           ProbePolicy
+	ShutdownPolicy                        ShutdownPolicy
+	CronJobPolicy                         CronJobPolicy
+	AutoscalingPolicy                     AutoscalingPolicy
+	ScorePolicy                           ScorePolicy
+}
+
+// ImagePolicy configures which container images are accepted
+type ImagePolicy struct {
+	// AllowedRegistries is a list of registries or repositories, such as "gcr.io" or "docker.io/library". If set, all images must match one of them
+	AllowedRegistries []string
+	// DeniedRegistries is a list of registries or repositories that images may not match
+	DeniedRegistries []string
+	// MutableTags is a list of tag patterns that are considered mutable, such as "main" or "dev-*"
+	MutableTags []string
+}
+
+// SecretPolicy configures the detection of secret material
+type SecretPolicy struct {
+	// Rules are additional detection rules on the format "name=regexp"
+	Rules []string
+	// DisabledRules are the names of rules to not use, such as "jwt" or "high-entropy-string"
+	DisabledRules map[string]struct{}
+	// EntropyThreshold is the Shannon entropy in bits per character above which a string is considered to be a secret. Defaults to 4.0 if not set
+	EntropyThreshold float64
+}
+
+// ResourcePolicy configures the bounds for container and pod resources
+// Bounds that are not set (zero) are not checked.
+type ResourcePolicy struct {
+	// MaxLimitRequestRatio is the highest allowed ratio between the limit and the request of a container resource
+	MaxLimitRequestRatio float64
+
+	MinContainerCPU    resource.Quantity
+	MaxContainerCPU    resource.Quantity
+	MinContainerMemory resource.Quantity
+	MaxContainerMemory resource.Quantity
+
+	// NodeCPU and NodeMemory are the allocatable resources of a node, pods requesting more will never be scheduled
+	NodeCPU    resource.Quantity
+	NodeMemory resource.Quantity
+
+	// CPUUnitThreshold is the number of whole cores from which a CPU quantity is assumed to be missing the "m" suffix
+	CPUUnitThreshold resource.Quantity
+	// MemoryUnitThreshold is the size below which a memory quantity is assumed to be missing a unit suffix
+	MemoryUnitThreshold resource.Quantity
+}
+
+// ProbePolicy configures the bounds for probe timing, all values are in seconds
+type ProbePolicy struct {
+	// MinFailureWindow and MaxFailureWindow are the bounds for failureThreshold * periodSeconds, not checked if zero
+	MinFailureWindow int32
+	MaxFailureWindow int32
+
+	// MinLivenessStartupWindow is the shortest time a container without a startupProbe is given to start,
+	// before the livenessProbe restarts it. Defaults to 30 seconds if nil, and is not checked if zero.
+	MinLivenessStartupWindow *int32
+}
+
+// ShutdownPolicy configures the graceful shutdown checks
+type ShutdownPolicy struct {
+	// MaxTerminationGracePeriod is the highest allowed terminationGracePeriodSeconds. Defaults to 600 seconds if zero.
+	MaxTerminationGracePeriod int64
+}
+
+// CronJobPolicy configures the CronJob checks
+type CronJobPolicy struct {
+	// MinScheduleInterval is the shortest allowed time between two runs of a CronJob. Defaults to 5 minutes if zero.
+	MinScheduleInterval time.Duration
+}
+
+// AutoscalingPolicy configures the HorizontalPodAutoscaler checks
+type AutoscalingPolicy struct {
+	// MaxReplicas is the highest allowed maxReplicas of a HorizontalPodAutoscaler. Defaults to 100 if zero.
+	MaxReplicas int32
+}
+
+// ScorePolicy configures how the score of objects is calculated
+type ScorePolicy struct {
+	// CheckWeights is the weight of each check ID in the score, checks that are not set have the weight 1. Checks with
+	// the weight 0 do not affect the score.
+	CheckWeights map[string]float64
+}
+
+// ParseCheckWeights parses check weights on the format "check-id=weight"
+func ParseCheckWeights(in []string) (map[string]float64, error) {
+	res := make(map[string]float64)
+	for _, v := range in {
+		id, weight, ok := strings.Cut(v, "=")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid check weight %q, expected the format 'check-id=weight'", v)
+		}
+		w, err := strconv.ParseFloat(weight, 64)
+		if err != nil || w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("invalid check weight %q, the weight must be a finite number that is 0 or higher", v)
+		}
+		res[id] = w
+	}
+	return res, nil
 }
 
 type Semver struct {
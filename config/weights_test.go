@@ -0,0 +1,18 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCheckWeights(t *testing.T) {
+	w, err := ParseCheckWeights([]string{"pod-probes=2", "container-image-tag=0.5", "service-type=0"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]float64{"pod-probes": 2, "container-image-tag": 0.5, "service-type": 0}, w)
+
+	for _, input := range []string{"pod-probes", "=2", "pod-probes=", "pod-probes=two", "pod-probes=-1", "pod-probes=NaN", "pod-probes=Inf", "pod-probes=+Inf", "pod-probes=infinity"} {
+		_, err := ParseCheckWeights([]string{input})
+		assert.NotNil(t, err, "Case: %s", input)
+	}
+}
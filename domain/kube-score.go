@@ -4,8 +4,10 @@ import (
 	"io"
 
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
@@ -28,6 +30,14 @@ type NamedReader interface {
 type FileLocation struct {
 	Name string
 	Line int
+
+	// Lines resolves the lines of the paths of comments inside of the object, it is nil if the lines are not known
+	Lines LineResolver `json:"-"`
+}
+
+// LineResolver finds the line of a comment path inside of an object, such as the name of a container or a field
+type LineResolver interface {
+	Line(path string) (int, bool)
 }
 
 type BothMeta struct {
@@ -51,7 +61,14 @@ type HpaTargeter interface {
 	GetTypeMeta() metav1.TypeMeta
 	GetObjectMeta() metav1.ObjectMeta
 	MinReplicas() *int32
+	MaxReplicas() int32
 	HpaTarget() autoscalingv1.CrossVersionObjectReference
+	// Metrics returns the metrics of the HPA, converted to autoscaling/v2
+	// An empty list means that the default metric (80% average CPU utilization) is used.
+	Metrics() []autoscalingv2.MetricSpec
+	// Behavior returns the scaling behavior of the HPA, converted to autoscaling/v2
+	// It is always nil for autoscaling/v1 and autoscaling/v2beta1, which don't support configuring the behavior.
+	Behavior() *autoscalingv2.HorizontalPodAutoscalerBehavior
 	FileLocationer
 }
 
@@ -59,6 +76,10 @@ type Ingress interface {
 	GetTypeMeta() metav1.TypeMeta
 	GetObjectMeta() metav1.ObjectMeta
 	Rules() []networkingv1.IngressRule
+	TLS() []networkingv1.IngressTLS
+	// DefaultBackend returns spec.defaultBackend, or spec.backend for the v1beta1 versions
+	DefaultBackend() *networkingv1.IngressBackend
+	IngressClassName() *string
 	FileLocationer
 }
 
@@ -88,6 +109,15 @@ type Services interface {
 	Services() []Service
 }
 
+type ConfigMap interface {
+	ConfigMap() corev1.ConfigMap
+	FileLocationer
+}
+
+type ConfigMaps interface {
+	ConfigMaps() []ConfigMap
+}
+
 type StatefulSet interface {
 	StatefulSet() appsv1.StatefulSet
 	FileLocationer
@@ -97,6 +127,15 @@ type StatefulSets interface {
 	StatefulSets() []StatefulSet
 }
 
+type DaemonSet interface {
+	DaemonSet() appsv1.DaemonSet
+	FileLocationer
+}
+
+type DaemonSets interface {
+	DaemonSets() []DaemonSet
+}
+
 type Deployment interface {
 	Deployment() appsv1.Deployment
 	FileLocationer
@@ -106,6 +145,15 @@ type Deployments interface {
 	Deployments() []Deployment
 }
 
+type Namespace interface {
+	Namespace() corev1.Namespace
+	FileLocationer
+}
+
+type Namespaces interface {
+	Namespaces() []Namespace
+}
+
 type NetworkPolicy interface {
 	NetworkPolicy() networkingv1.NetworkPolicy
 	FileLocationer
@@ -119,11 +167,23 @@ type Ingresses interface {
 	Ingresses() []Ingress
 }
 
+type Job interface {
+	Job() batchv1.Job
+	FileLocationer
+}
+
+type Jobs interface {
+	Jobs() []Job
+}
+
 type CronJob interface {
 	GetTypeMeta() metav1.TypeMeta
 	GetObjectMeta() metav1.ObjectMeta
 	StartingDeadlineSeconds() *int64
 	GetPodTemplateSpec() corev1.PodTemplateSpec
+
+	// Spec returns the spec of the CronJob, all versions of CronJobs are converted to batch/v1
+	Spec() batchv1.CronJobSpec
 	FileLocationer
 }
 
@@ -148,16 +208,36 @@ type HorizontalPodAutoscalers interface {
 	HorizontalPodAutoscalers() []HpaTargeter
 }
 
+type VerticalPodAutoscaler interface {
+	GetTypeMeta() metav1.TypeMeta
+	GetObjectMeta() metav1.ObjectMeta
+	VpaTarget() autoscalingv1.CrossVersionObjectReference
+	// UpdateMode returns the update mode of the VPA, defaults to "Auto"
+	UpdateMode() string
+	// ControlledResources returns the resources that are controlled by the VPA in at least one container
+	ControlledResources() []corev1.ResourceName
+	FileLocationer
+}
+
+type VerticalPodAutoscalers interface {
+	VerticalPodAutoscalers() []VerticalPodAutoscaler
+}
+
 type AllTypes interface {
 	Metas
 	Pods
 	PodSpeccers
 	Services
+	ConfigMaps
 	StatefulSets
+	DaemonSets
 	Deployments
 	NetworkPolicies
 	Ingresses
+	Jobs
 	CronJobs
 	PodDisruptionBudgets
 	HorizontalPodAutoscalers
+	VerticalPodAutoscalers
+	Namespaces
 }
@@ -33,3 +33,7 @@ func (c CronJobV1) GetPodTemplateSpec() corev1.PodTemplateSpec {
 	t.ObjectMeta.Namespace = c.Obj.ObjectMeta.Namespace
 	return t
 }
+
+func (c CronJobV1) Spec() v1.CronJobSpec {
+	return c.Obj.Spec
+}
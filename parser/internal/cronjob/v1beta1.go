@@ -2,6 +2,7 @@ package cronjob
 
 import (
 	ks "github.com/zegl/kube-score/domain"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,3 +34,21 @@ func (c CronJobV1beta1) GetPodTemplateSpec() corev1.PodTemplateSpec {
 	t.ObjectMeta.Namespace = c.Obj.ObjectMeta.Namespace
 	return t
 }
+
+// Spec returns the spec converted to batch/v1
+func (c CronJobV1beta1) Spec() batchv1.CronJobSpec {
+	s := c.Obj.Spec
+	return batchv1.CronJobSpec{
+		Schedule:                s.Schedule,
+		TimeZone:                s.TimeZone,
+		StartingDeadlineSeconds: s.StartingDeadlineSeconds,
+		ConcurrencyPolicy:       batchv1.ConcurrencyPolicy(s.ConcurrencyPolicy),
+		Suspend:                 s.Suspend,
+		JobTemplate: batchv1.JobTemplateSpec{
+			ObjectMeta: s.JobTemplate.ObjectMeta,
+			Spec:       s.JobTemplate.Spec,
+		},
+		SuccessfulJobsHistoryLimit: s.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     s.FailedJobsHistoryLimit,
+	}
+}
@@ -5,6 +5,8 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	ks "github.com/zegl/kube-score/domain"
@@ -34,6 +36,18 @@ func (d HPAv1) HpaTarget() autoscalingv1.CrossVersionObjectReference {
 	return d.Spec.ScaleTargetRef
 }
 
+func (d HPAv1) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
+
+func (d HPAv1) Metrics() []autoscalingv2.MetricSpec {
+	return v1Metrics(d.Spec.TargetCPUUtilizationPercentage)
+}
+
+func (d HPAv1) Behavior() *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	return nil
+}
+
 type HPAv2beta1 struct {
 	autoscalingv2beta1.HorizontalPodAutoscaler
 	Location ks.FileLocation
@@ -59,6 +73,18 @@ func (d HPAv2beta1) HpaTarget() autoscalingv1.CrossVersionObjectReference {
 	return autoscalingv1.CrossVersionObjectReference(d.Spec.ScaleTargetRef)
 }
 
+func (d HPAv2beta1) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
+
+func (d HPAv2beta1) Metrics() []autoscalingv2.MetricSpec {
+	return v2beta1Metrics(d.Spec.Metrics)
+}
+
+func (d HPAv2beta1) Behavior() *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	return nil
+}
+
 type HPAv2beta2 struct {
 	autoscalingv2beta2.HorizontalPodAutoscaler
 	Location ks.FileLocation
@@ -84,6 +110,18 @@ func (d HPAv2beta2) HpaTarget() autoscalingv1.CrossVersionObjectReference {
 	return autoscalingv1.CrossVersionObjectReference(d.Spec.ScaleTargetRef)
 }
 
+func (d HPAv2beta2) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
+
+func (d HPAv2beta2) Metrics() []autoscalingv2.MetricSpec {
+	return v2beta2Metrics(d.Spec.Metrics)
+}
+
+func (d HPAv2beta2) Behavior() *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	return v2beta2Behavior(d.Spec.Behavior)
+}
+
 type HPAv2 struct {
 	autoscalingv2.HorizontalPodAutoscaler
 	Location ks.FileLocation
@@ -108,3 +146,168 @@ func (d HPAv2) MinReplicas() *int32 {
 func (d HPAv2) HpaTarget() autoscalingv1.CrossVersionObjectReference {
 	return autoscalingv1.CrossVersionObjectReference(d.Spec.ScaleTargetRef)
 }
+
+func (d HPAv2) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
+
+func (d HPAv2) Metrics() []autoscalingv2.MetricSpec {
+	return d.Spec.Metrics
+}
+
+func (d HPAv2) Behavior() *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	return d.Spec.Behavior
+}
+
+// v1Metrics converts the CPU target of an autoscaling/v1 HPA to an autoscaling/v2 metric
+func v1Metrics(targetCPUUtilizationPercentage *int32) []autoscalingv2.MetricSpec {
+	if targetCPUUtilizationPercentage == nil {
+		return nil
+	}
+	return []autoscalingv2.MetricSpec{{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: corev1.ResourceCPU,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: targetCPUUtilizationPercentage,
+			},
+		},
+	}}
+}
+
+func v2beta1Metrics(in []autoscalingv2beta1.MetricSpec) []autoscalingv2.MetricSpec {
+	var res []autoscalingv2.MetricSpec
+	for _, m := range in {
+		out := autoscalingv2.MetricSpec{Type: autoscalingv2.MetricSourceType(m.Type)}
+		if m.Resource != nil {
+			out.Resource = &autoscalingv2.ResourceMetricSource{
+				Name:   m.Resource.Name,
+				Target: v2beta1ResourceTarget(m.Resource.TargetAverageUtilization, m.Resource.TargetAverageValue),
+			}
+		}
+		if m.ContainerResource != nil {
+			out.ContainerResource = &autoscalingv2.ContainerResourceMetricSource{
+				Name:      m.ContainerResource.Name,
+				Container: m.ContainerResource.Container,
+				Target:    v2beta1ResourceTarget(m.ContainerResource.TargetAverageUtilization, m.ContainerResource.TargetAverageValue),
+			}
+		}
+		if m.Pods != nil {
+			averageValue := m.Pods.TargetAverageValue
+			out.Pods = &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: m.Pods.MetricName, Selector: m.Pods.Selector},
+				Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: &averageValue},
+			}
+		}
+		if m.Object != nil {
+			target := autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: m.Object.AverageValue}
+			if m.Object.AverageValue == nil {
+				value := m.Object.TargetValue
+				target = autoscalingv2.MetricTarget{Type: autoscalingv2.ValueMetricType, Value: &value}
+			}
+			out.Object = &autoscalingv2.ObjectMetricSource{
+				DescribedObject: autoscalingv2.CrossVersionObjectReference(m.Object.Target),
+				Metric:          autoscalingv2.MetricIdentifier{Name: m.Object.MetricName, Selector: m.Object.Selector},
+				Target:          target,
+			}
+		}
+		if m.External != nil {
+			target := autoscalingv2.MetricTarget{Type: autoscalingv2.ValueMetricType, Value: m.External.TargetValue}
+			if m.External.TargetAverageValue != nil {
+				target = autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: m.External.TargetAverageValue}
+			}
+			out.External = &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: m.External.MetricName, Selector: m.External.MetricSelector},
+				Target: target,
+			}
+		}
+		res = append(res, out)
+	}
+	return res
+}
+
+func v2beta1ResourceTarget(averageUtilization *int32, averageValue *resource.Quantity) autoscalingv2.MetricTarget {
+	if averageUtilization != nil {
+		return autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: averageUtilization}
+	}
+	return autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: averageValue}
+}
+
+func v2beta2Metrics(in []autoscalingv2beta2.MetricSpec) []autoscalingv2.MetricSpec {
+	var res []autoscalingv2.MetricSpec
+	for _, m := range in {
+		out := autoscalingv2.MetricSpec{Type: autoscalingv2.MetricSourceType(m.Type)}
+		if m.Resource != nil {
+			out.Resource = &autoscalingv2.ResourceMetricSource{
+				Name:   m.Resource.Name,
+				Target: v2beta2Target(m.Resource.Target),
+			}
+		}
+		if m.ContainerResource != nil {
+			out.ContainerResource = &autoscalingv2.ContainerResourceMetricSource{
+				Name:      m.ContainerResource.Name,
+				Container: m.ContainerResource.Container,
+				Target:    v2beta2Target(m.ContainerResource.Target),
+			}
+		}
+		if m.Pods != nil {
+			out.Pods = &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier(m.Pods.Metric),
+				Target: v2beta2Target(m.Pods.Target),
+			}
+		}
+		if m.Object != nil {
+			out.Object = &autoscalingv2.ObjectMetricSource{
+				DescribedObject: autoscalingv2.CrossVersionObjectReference(m.Object.DescribedObject),
+				Metric:          autoscalingv2.MetricIdentifier(m.Object.Metric),
+				Target:          v2beta2Target(m.Object.Target),
+			}
+		}
+		if m.External != nil {
+			out.External = &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier(m.External.Metric),
+				Target: v2beta2Target(m.External.Target),
+			}
+		}
+		res = append(res, out)
+	}
+	return res
+}
+
+func v2beta2Target(in autoscalingv2beta2.MetricTarget) autoscalingv2.MetricTarget {
+	return autoscalingv2.MetricTarget{
+		Type:               autoscalingv2.MetricTargetType(in.Type),
+		Value:              in.Value,
+		AverageValue:       in.AverageValue,
+		AverageUtilization: in.AverageUtilization,
+	}
+}
+
+func v2beta2Behavior(in *autoscalingv2beta2.HorizontalPodAutoscalerBehavior) *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	if in == nil {
+		return nil
+	}
+	return &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp:   v2beta2ScalingRules(in.ScaleUp),
+		ScaleDown: v2beta2ScalingRules(in.ScaleDown),
+	}
+}
+
+func v2beta2ScalingRules(in *autoscalingv2beta2.HPAScalingRules) *autoscalingv2.HPAScalingRules {
+	if in == nil {
+		return nil
+	}
+	out := &autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: in.StabilizationWindowSeconds,
+		SelectPolicy:               (*autoscalingv2.ScalingPolicySelect)(in.SelectPolicy),
+	}
+	for _, p := range in.Policies {
+		out.Policies = append(out.Policies, autoscalingv2.HPAScalingPolicy{
+			Type:          autoscalingv2.HPAScalingPolicyType(p.Type),
+			Value:         p.Value,
+			PeriodSeconds: p.PeriodSeconds,
+		})
+	}
+	return out
+}
@@ -1,10 +1,12 @@
 package internal
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	networkingv1 "k8s.io/api/networking/v1"
 	networkingv1beta1 "k8s.io/api/networking/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	ks "github.com/zegl/kube-score/domain"
 )
@@ -34,6 +36,18 @@ func (i IngressV1) Rules() []networkingv1.IngressRule {
 	return i.Spec.Rules
 }
 
+func (i IngressV1) TLS() []networkingv1.IngressTLS {
+	return i.Spec.TLS
+}
+
+func (i IngressV1) DefaultBackend() *networkingv1.IngressBackend {
+	return i.Spec.DefaultBackend
+}
+
+func (i IngressV1) IngressClassName() *string {
+	return i.Spec.IngressClassName
+}
+
 type IngressV1beta1 struct {
 	networkingv1beta1.Ingress
 	Location ks.FileLocation
@@ -57,16 +71,9 @@ func (i IngressV1beta1) Rules() []networkingv1.IngressRule {
 	paths := func(in []networkingv1beta1.HTTPIngressPath) (out []networkingv1.HTTPIngressPath) {
 		for _, path := range in {
 			out = append(out, networkingv1.HTTPIngressPath{
-				Path: path.Path,
-				Backend: networkingv1.IngressBackend{
-					Service: &networkingv1.IngressServiceBackend{
-						Name: path.Backend.ServiceName,
-						Port: networkingv1.ServiceBackendPort{
-							Name:   path.Backend.ServicePort.StrVal,
-							Number: path.Backend.ServicePort.IntVal,
-						},
-					},
-				},
+				Path:     path.Path,
+				PathType: (*networkingv1.PathType)(path.PathType),
+				Backend:  v1beta1Backend(path.Backend.ServiceName, path.Backend.ServicePort, path.Backend.Resource),
 			})
 		}
 		return
@@ -91,6 +98,26 @@ func (i IngressV1beta1) Rules() []networkingv1.IngressRule {
 	return res
 }
 
+func (i IngressV1beta1) TLS() []networkingv1.IngressTLS {
+	var res []networkingv1.IngressTLS
+	for _, tls := range i.Spec.TLS {
+		res = append(res, networkingv1.IngressTLS{Hosts: tls.Hosts, SecretName: tls.SecretName})
+	}
+	return res
+}
+
+func (i IngressV1beta1) DefaultBackend() *networkingv1.IngressBackend {
+	if i.Spec.Backend == nil {
+		return nil
+	}
+	backend := v1beta1Backend(i.Spec.Backend.ServiceName, i.Spec.Backend.ServicePort, i.Spec.Backend.Resource)
+	return &backend
+}
+
+func (i IngressV1beta1) IngressClassName() *string {
+	return i.Spec.IngressClassName
+}
+
 type ExtensionsIngressV1beta1 struct {
 	extensionsv1beta1.Ingress
 	Location ks.FileLocation
@@ -110,16 +137,9 @@ func (i ExtensionsIngressV1beta1) Rules() []networkingv1.IngressRule {
 	paths := func(in []extensionsv1beta1.HTTPIngressPath) (out []networkingv1.HTTPIngressPath) {
 		for _, path := range in {
 			out = append(out, networkingv1.HTTPIngressPath{
-				Path: path.Path,
-				Backend: networkingv1.IngressBackend{
-					Service: &networkingv1.IngressServiceBackend{
-						Name: path.Backend.ServiceName,
-						Port: networkingv1.ServiceBackendPort{
-							Name:   path.Backend.ServicePort.StrVal,
-							Number: path.Backend.ServicePort.IntVal,
-						},
-					},
-				},
+				Path:     path.Path,
+				PathType: (*networkingv1.PathType)(path.PathType),
+				Backend:  v1beta1Backend(path.Backend.ServiceName, path.Backend.ServicePort, path.Backend.Resource),
 			})
 		}
 		return
@@ -144,6 +164,44 @@ func (i ExtensionsIngressV1beta1) Rules() []networkingv1.IngressRule {
 	return res
 }
 
+func (i ExtensionsIngressV1beta1) TLS() []networkingv1.IngressTLS {
+	var res []networkingv1.IngressTLS
+	for _, tls := range i.Spec.TLS {
+		res = append(res, networkingv1.IngressTLS{Hosts: tls.Hosts, SecretName: tls.SecretName})
+	}
+	return res
+}
+
+func (i ExtensionsIngressV1beta1) DefaultBackend() *networkingv1.IngressBackend {
+	if i.Spec.Backend == nil {
+		return nil
+	}
+	backend := v1beta1Backend(i.Spec.Backend.ServiceName, i.Spec.Backend.ServicePort, i.Spec.Backend.Resource)
+	return &backend
+}
+
+func (i ExtensionsIngressV1beta1) IngressClassName() *string {
+	return i.Spec.IngressClassName
+}
+
 func (i ExtensionsIngressV1beta1) FileLocation() ks.FileLocation {
 	return i.Location
 }
+
+// v1beta1Backend converts a backend from networking/v1beta1 or extensions/v1beta1, which have the same fields
+// The service is left empty for resource backends.
+func v1beta1Backend(serviceName string, servicePort intstr.IntOrString, resource *corev1.TypedLocalObjectReference) networkingv1.IngressBackend {
+	backend := networkingv1.IngressBackend{
+		Resource: resource,
+	}
+	if resource == nil || serviceName != "" {
+		backend.Service = &networkingv1.IngressServiceBackend{
+			Name: serviceName,
+			Port: networkingv1.ServiceBackendPort{
+				Name:   servicePort.StrVal,
+				Number: servicePort.IntVal,
+			},
+		}
+	}
+	return backend
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ks "github.com/zegl/kube-score/domain"
+)
+
+// VerticalPodAutoscaler is the subset of autoscaling.k8s.io/v1 VerticalPodAutoscaler that is used by kube-score
+// The full type is defined in k8s.io/autoscaler, which is not a dependency of kube-score.
+type VerticalPodAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VerticalPodAutoscalerSpec `json:"spec"`
+}
+
+type VerticalPodAutoscalerSpec struct {
+	TargetRef      *autoscalingv1.CrossVersionObjectReference `json:"targetRef"`
+	UpdatePolicy   *VPAUpdatePolicy                           `json:"updatePolicy,omitempty"`
+	ResourcePolicy *VPAResourcePolicy                         `json:"resourcePolicy,omitempty"`
+}
+
+type VPAUpdatePolicy struct {
+	UpdateMode *string `json:"updateMode,omitempty"`
+}
+
+type VPAResourcePolicy struct {
+	ContainerPolicies []VPAContainerResourcePolicy `json:"containerPolicies,omitempty"`
+}
+
+type VPAContainerResourcePolicy struct {
+	ContainerName       string                 `json:"containerName,omitempty"`
+	Mode                *string                `json:"mode,omitempty"`
+	ControlledResources *[]corev1.ResourceName `json:"controlledResources,omitempty"`
+}
+
+type VPA struct {
+	Obj      VerticalPodAutoscaler
+	Location ks.FileLocation
+}
+
+func (d VPA) FileLocation() ks.FileLocation {
+	return d.Location
+}
+
+func (d VPA) GetTypeMeta() metav1.TypeMeta {
+	return d.Obj.TypeMeta
+}
+
+func (d VPA) GetObjectMeta() metav1.ObjectMeta {
+	return d.Obj.ObjectMeta
+}
+
+func (d VPA) VpaTarget() autoscalingv1.CrossVersionObjectReference {
+	if d.Obj.Spec.TargetRef == nil {
+		return autoscalingv1.CrossVersionObjectReference{}
+	}
+	return *d.Obj.Spec.TargetRef
+}
+
+func (d VPA) UpdateMode() string {
+	if d.Obj.Spec.UpdatePolicy == nil || d.Obj.Spec.UpdatePolicy.UpdateMode == nil {
+		return "Auto"
+	}
+	return *d.Obj.Spec.UpdatePolicy.UpdateMode
+}
+
+func (d VPA) ControlledResources() []corev1.ResourceName {
+	defaultResources := []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+	if d.Obj.Spec.ResourcePolicy == nil || len(d.Obj.Spec.ResourcePolicy.ContainerPolicies) == 0 {
+		return defaultResources
+	}
+
+	seen := make(map[corev1.ResourceName]struct{})
+	var res []corev1.ResourceName
+	for _, policy := range d.Obj.Spec.ResourcePolicy.ContainerPolicies {
+		if policy.Mode != nil && *policy.Mode == "Off" {
+			continue
+		}
+		resources := defaultResources
+		if policy.ControlledResources != nil {
+			resources = *policy.ControlledResources
+		}
+		for _, r := range resources {
+			if _, ok := seen[r]; !ok {
+				seen[r] = struct{}{}
+				res = append(res, r)
+			}
+		}
+	}
+	return res
+}
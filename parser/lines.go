@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlLines resolves the lines of comment paths in a YAML document
+// The document is only parsed if a line is requested, which is not the case for most output formats.
+type yamlLines struct {
+	raw    []byte
+	offset int
+
+	once sync.Once
+	root *yaml.Node
+}
+
+func newYAMLLines(raw []byte, offset int) *yamlLines {
+	return &yamlLines{raw: raw, offset: offset}
+}
+
+// Line returns the line in the file of the node that the path refers to
+//
+// The path is tried as a field path (such as "ingress[0]") from the root and from the spec of the object, then as the
+// name of a list item (such as a container, port or volume), then as a mapping key, and last as a scalar value.
+func (l *yamlLines) Line(path string) (int, bool) {
+	l.once.Do(func() {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(l.raw, &doc); err == nil && len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+			l.root = doc.Content[0]
+		}
+	})
+	if l.root == nil || path == "" {
+		return 0, false
+	}
+
+	if n := fieldPath(l.root, path); n != nil {
+		return l.line(n), true
+	}
+	if n := fieldPath(mappingValue(l.root, "spec"), path); n != nil {
+		return l.line(n), true
+	}
+
+	// The metadata of the object is skipped, so that the name of the object does not match the name of a container
+	var nodes []*yaml.Node
+	for i := 0; i+1 < len(l.root.Content); i += 2 {
+		if l.root.Content[i].Value != "metadata" {
+			nodes = append(nodes, l.root.Content[i+1])
+		}
+	}
+
+	matchers := []func(n *yaml.Node) bool{
+		func(n *yaml.Node) bool {
+			name := mappingValue(n, "name")
+			return name != nil && name.Kind == yaml.ScalarNode && name.Value == path
+		},
+		func(n *yaml.Node) bool {
+			return n.Kind == yaml.MappingNode && mappingValue(n, path) != nil
+		},
+		func(n *yaml.Node) bool {
+			return n.Kind == yaml.ScalarNode && n.Value == path
+		},
+	}
+	for i, match := range matchers {
+		for _, root := range nodes {
+			if n := find(root, match); n != nil {
+				// Point at the key instead of the mapping
+				if i == 1 {
+					n = mappingKey(n, path)
+				}
+				return l.line(n), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func (l *yamlLines) line(n *yaml.Node) int {
+	return l.offset + n.Line - 1
+}
+
+// fieldPath returns the node at a path on the format "a.b[1].c", or nil if it does not exist
+func fieldPath(n *yaml.Node, path string) *yaml.Node {
+	for _, segment := range strings.Split(path, ".") {
+		if n == nil {
+			return nil
+		}
+		key, index, hasIndex := strings.Cut(segment, "[")
+		n = mappingValue(n, key)
+		if hasIndex {
+			i, err := strconv.Atoi(strings.TrimSuffix(index, "]"))
+			if err != nil || n == nil || n.Kind != yaml.SequenceNode || i < 0 || i >= len(n.Content) {
+				return nil
+			}
+			n = n.Content[i]
+		}
+	}
+	return n
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func mappingKey(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i]
+		}
+	}
+	return n
+}
+
+// find returns the first node in document order that matches
+func find(n *yaml.Node, match func(*yaml.Node) bool) *yaml.Node {
+	if match(n) {
+		return n
+	}
+	for _, c := range n.Content {
+		if res := find(c, match); res != nil {
+			return res
+		}
+	}
+	return nil
+}
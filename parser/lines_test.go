@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ks "github.com/zegl/kube-score/domain"
+)
+
+func TestFileLocationLines(t *testing.T) {
+	doc := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  app.properties: foo=bar
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: sidecar
+        image: foo:bar
+      - name: app
+        image: foo:bar
+        ports:
+        - containerPort: 8080
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: foo
+spec:
+  podSelector: {}
+  egress:
+  - {}`
+
+	parser, err := New(nil)
+	assert.NoError(t, err)
+	parsed, err := parser.ParseFiles([]ks.NamedReader{namedReader{Reader: strings.NewReader(doc), name: "all.yaml"}})
+	assert.NoError(t, err)
+
+	lines := func(name string) ks.LineResolver {
+		for _, m := range parsed.Metas() {
+			if m.ObjectMeta.Name == name {
+				return m.FileLocation().Lines
+			}
+		}
+		t.Fatalf("object %s not found", name)
+		return nil
+	}
+
+	for _, tc := range []struct {
+		object string
+		path   string
+		line   int
+		ok     bool
+	}{
+		{"config", "app.properties", 6, true},
+		{"app", "app", 18, true},
+		{"app", "sidecar", 16, true},
+		{"app", "8080", 21, true},
+		{"app", "missing", 0, false},
+		{"foo", "egress[0]", 30, true},
+		{"foo", "egress[1]", 0, false},
+	} {
+		line, ok := lines(tc.object).Line(tc.path)
+		assert.Equal(t, tc.ok, ok, tc.path)
+		assert.Equal(t, tc.line, line, tc.path)
+	}
+}
+
+func TestFileLocationLinesInList(t *testing.T) {
+	doc := `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: config`
+
+	parser, err := New(nil)
+	assert.NoError(t, err)
+	parsed, err := parser.ParseFiles([]ks.NamedReader{namedReader{Reader: strings.NewReader(doc), name: "list.yaml"}})
+	assert.NoError(t, err)
+	assert.Len(t, parsed.Metas(), 1)
+	assert.Nil(t, parsed.Metas()[0].FileLocation().Lines)
+}
@@ -26,9 +26,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
 
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/parser/internal"
+	internalconfigmap "github.com/zegl/kube-score/parser/internal/configmap"
 	internalcronjob "github.com/zegl/kube-score/parser/internal/cronjob"
 	internalnetpol "github.com/zegl/kube-score/parser/internal/networkpolicy"
 	internalpdb "github.com/zegl/kube-score/parser/internal/pdb"
@@ -36,6 +38,11 @@ import (
 	internalservice "github.com/zegl/kube-score/parser/internal/service"
 )
 
+var (
+	vpaV1      = schema.GroupVersion{Group: "autoscaling.k8s.io", Version: "v1"}
+	vpaV1beta2 = schema.GroupVersion{Group: "autoscaling.k8s.io", Version: "v1beta2"}
+)
+
 type Parser struct {
 	scheme *runtime.Scheme
 	codecs serializer.CodecFactory
@@ -99,18 +106,27 @@ type parsedObjects struct {
 	podspecers           []ks.PodSpecer
 	networkPolicies      []ks.NetworkPolicy
 	services             []ks.Service
+	configMaps           []ks.ConfigMap
 	podDisruptionBudgets []ks.PodDisruptionBudget
 	deployments          []ks.Deployment
 	statefulsets         []ks.StatefulSet
+	daemonsets           []ks.DaemonSet
 	ingresses            []ks.Ingress // supports multiple versions of ingress
+	jobs                 []ks.Job
 	cronjobs             []ks.CronJob
 	hpaTargeters         []ks.HpaTargeter // all versions of HPAs
+	vpas                 []ks.VerticalPodAutoscaler
+	namespaces           []ks.Namespace
 }
 
 func (p *parsedObjects) Services() []ks.Service {
 	return p.services
 }
 
+func (p *parsedObjects) ConfigMaps() []ks.ConfigMap {
+	return p.configMaps
+}
+
 func (p *parsedObjects) Pods() []ks.Pod {
 	return p.pods
 }
@@ -127,6 +143,10 @@ func (p *parsedObjects) PodDisruptionBudgets() []ks.PodDisruptionBudget {
 	return p.podDisruptionBudgets
 }
 
+func (p *parsedObjects) Jobs() []ks.Job {
+	return p.jobs
+}
+
 func (p *parsedObjects) CronJobs() []ks.CronJob {
 	return p.cronjobs
 }
@@ -139,6 +159,10 @@ func (p *parsedObjects) StatefulSets() []ks.StatefulSet {
 	return p.statefulsets
 }
 
+func (p *parsedObjects) DaemonSets() []ks.DaemonSet {
+	return p.daemonsets
+}
+
 func (p *parsedObjects) Metas() []ks.BothMeta {
 	return p.bothMetas
 }
@@ -151,6 +175,14 @@ func (p *parsedObjects) HorizontalPodAutoscalers() []ks.HpaTargeter {
 	return p.hpaTargeters
 }
 
+func (p *parsedObjects) VerticalPodAutoscalers() []ks.VerticalPodAutoscaler {
+	return p.vpas
+}
+
+func (p *parsedObjects) Namespaces() []ks.Namespace {
+	return p.namespaces
+}
+
 func Empty() ks.AllTypes {
 	return &parsedObjects{}
 }
@@ -178,7 +210,7 @@ func (p *Parser) ParseFiles(files []ks.NamedReader) (ks.AllTypes, error) {
 		for _, fileContents := range bytes.Split(fullFile, []byte("\n---\n")) {
 
 			if len(bytes.TrimSpace(fileContents)) > 0 {
-				if err := p.detectAndDecode(s, namedReader.Name(), offset, fileContents); err != nil {
+				if err := p.detectAndDecode(s, namedReader.Name(), offset, fileContents, false); err != nil {
 					return nil, err
 				}
 			}
@@ -190,7 +222,7 @@ func (p *Parser) ParseFiles(files []ks.NamedReader) (ks.AllTypes, error) {
 	return s, nil
 }
 
-func (p *Parser) detectAndDecode(s *parsedObjects, fileName string, fileOffset int, raw []byte) error {
+func (p *Parser) detectAndDecode(s *parsedObjects, fileName string, fileOffset int, raw []byte, inList bool) error {
 	var detect detectKind
 	err := yaml.Unmarshal(raw, &detect)
 	if err != nil {
@@ -207,7 +239,7 @@ func (p *Parser) detectAndDecode(s *parsedObjects, fileName string, fileOffset i
 			return err
 		}
 		for _, listItem := range list.Items {
-			err := p.detectAndDecode(s, fileName, fileOffset, listItem.Raw)
+			err := p.detectAndDecode(s, fileName, fileOffset, listItem.Raw, true)
 			if err != nil {
 				return err
 			}
@@ -215,7 +247,7 @@ func (p *Parser) detectAndDecode(s *parsedObjects, fileName string, fileOffset i
 		return nil
 	}
 
-	err = p.decodeItem(s, detectedVersion, fileName, fileOffset, raw)
+	err = p.decodeItem(s, detectedVersion, fileName, fileOffset, raw, inList)
 	if err != nil {
 		return err
 	}
@@ -245,12 +277,13 @@ func detectFileLocation(fileName string, fileOffset int, fileContents []byte) ks
 	}
 
 	return ks.FileLocation{
-		Name: fileName,
-		Line: fileOffset,
+		Name:  fileName,
+		Line:  fileOffset,
+		Lines: newYAMLLines(fileContents, fileOffset),
 	}
 }
 
-func (p *Parser) decodeItem(s *parsedObjects, detectedVersion schema.GroupVersionKind, fileName string, fileOffset int, fileContents []byte) error {
+func (p *Parser) decodeItem(s *parsedObjects, detectedVersion schema.GroupVersionKind, fileName string, fileOffset int, fileContents []byte, inList bool) error {
 	addPodSpeccer := func(ps ks.PodSpecer) {
 		s.podspecers = append(s.podspecers, ps)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{
@@ -261,6 +294,10 @@ func (p *Parser) decodeItem(s *parsedObjects, detectedVersion schema.GroupVersio
 	}
 
 	fileLocation := detectFileLocation(fileName, fileOffset, fileContents)
+	if inList {
+		// The items of a List are decoded from JSON, and the lines inside of them are not known
+		fileLocation.Lines = nil
+	}
 
 	var errs parseErrors
 
@@ -272,10 +309,17 @@ func (p *Parser) decodeItem(s *parsedObjects, detectedVersion schema.GroupVersio
 		s.pods = append(s.pods, p)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{TypeMeta: pod.TypeMeta, ObjectMeta: pod.ObjectMeta, FileLocationer: p})
 
+	case corev1.SchemeGroupVersion.WithKind("Namespace"):
+		var namespace corev1.Namespace
+		errs.AddIfErr(p.decode(fileContents, &namespace))
+		s.namespaces = append(s.namespaces, internal.Namespace{Obj: namespace, Location: fileLocation})
+
 	case batchv1.SchemeGroupVersion.WithKind("Job"):
 		var job batchv1.Job
 		errs.AddIfErr(p.decode(fileContents, &job))
-		addPodSpeccer(internal.Batchv1Job{Job: job, Location: fileLocation})
+		j := internal.Batchv1Job{Obj: job, Location: fileLocation}
+		addPodSpeccer(j)
+		s.jobs = append(s.jobs, j)
 
 	case batchv1beta1.SchemeGroupVersion.WithKind("CronJob"):
 		var cronjob batchv1beta1.CronJob
@@ -332,7 +376,11 @@ func (p *Parser) decodeItem(s *parsedObjects, detectedVersion schema.GroupVersio
 	case appsv1.SchemeGroupVersion.WithKind("DaemonSet"):
 		var daemonset appsv1.DaemonSet
 		errs.AddIfErr(p.decode(fileContents, &daemonset))
-		addPodSpeccer(internal.Appsv1DaemonSet{DaemonSet: daemonset, Location: fileLocation})
+		ds := internal.Appsv1DaemonSet{Obj: daemonset, Location: fileLocation}
+		addPodSpeccer(ds)
+
+		// TODO: Support older versions of DaemonSet as well?
+		s.daemonsets = append(s.daemonsets, ds)
 	case appsv1beta2.SchemeGroupVersion.WithKind("DaemonSet"):
 		var daemonset appsv1beta2.DaemonSet
 		errs.AddIfErr(p.decode(fileContents, &daemonset))
@@ -356,6 +404,13 @@ func (p *Parser) decodeItem(s *parsedObjects, detectedVersion schema.GroupVersio
 		s.services = append(s.services, serv)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{TypeMeta: service.TypeMeta, ObjectMeta: service.ObjectMeta, FileLocationer: serv})
 
+	case corev1.SchemeGroupVersion.WithKind("ConfigMap"):
+		var configMap corev1.ConfigMap
+		errs.AddIfErr(p.decode(fileContents, &configMap))
+		cm := internalconfigmap.ConfigMap{Obj: configMap, Location: fileLocation}
+		s.configMaps = append(s.configMaps, cm)
+		s.bothMetas = append(s.bothMetas, ks.BothMeta{TypeMeta: configMap.TypeMeta, ObjectMeta: configMap.ObjectMeta, FileLocationer: cm})
+
 	case policyv1beta1.SchemeGroupVersion.WithKind("PodDisruptionBudget"):
 		var disruptBudget policyv1beta1.PodDisruptionBudget
 		errs.AddIfErr(p.decode(fileContents, &disruptBudget))
@@ -426,6 +481,17 @@ func (p *Parser) decodeItem(s *parsedObjects, detectedVersion schema.GroupVersio
 		s.hpaTargeters = append(s.hpaTargeters, h)
 		s.bothMetas = append(s.bothMetas, ks.BothMeta{TypeMeta: hpa.TypeMeta, ObjectMeta: hpa.ObjectMeta, FileLocationer: h})
 
+	case vpaV1.WithKind("VerticalPodAutoscaler"), vpaV1beta2.WithKind("VerticalPodAutoscaler"):
+		// The VPA types are not part of k8s.io/api, decode a subset of the object without the scheme
+		var vpa internal.VerticalPodAutoscaler
+		if err := yamlutil.Unmarshal(fileContents, &vpa); err != nil {
+			errs.AddIfErr(fmt.Errorf("Failed to parse %s: err=%w", detectedVersion, err))
+			break
+		}
+		v := internal.VPA{Obj: vpa, Location: fileLocation}
+		s.vpas = append(s.vpas, v)
+		s.bothMetas = append(s.bothMetas, ks.BothMeta{TypeMeta: vpa.TypeMeta, ObjectMeta: vpa.ObjectMeta, FileLocationer: v})
+
 	default:
 		if p.config.VerboseOutput > 1 {
 			log.Printf("Unknown datatype: %s", detectedVersion.String())
@@ -2,9 +2,14 @@ package parser
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"testing"
 
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+
 	ks "github.com/zegl/kube-score/domain"
 
 	"github.com/stretchr/testify/assert"
@@ -73,3 +78,94 @@ spec:
 	assert.Equal(t, "someName", fl.Name)
 	assert.Equal(t, 123, fl.Line)
 }
+
+func TestParseVerticalPodAutoscaler(t *testing.T) {
+	doc := `apiVersion: autoscaling.k8s.io/v1
+kind: VerticalPodAutoscaler
+metadata:
+  name: foo
+spec:
+  targetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: foo
+  updatePolicy:
+    updateMode: Recreate
+  resourcePolicy:
+    containerPolicies:
+    - containerName: sidecar
+      mode: "Off"
+    - containerName: '*'
+      controlledResources: [memory]`
+
+	parser, err := New(nil)
+	assert.NoError(t, err)
+	parsed, err := parser.ParseFiles([]ks.NamedReader{namedReader{Reader: strings.NewReader(doc), name: "vpa.yaml"}})
+	assert.NoError(t, err)
+
+	vpas := parsed.VerticalPodAutoscalers()
+	assert.Len(t, vpas, 1)
+	assert.Equal(t, "foo", vpas[0].VpaTarget().Name)
+	assert.Equal(t, "Recreate", vpas[0].UpdateMode())
+	assert.Equal(t, []corev1.ResourceName{corev1.ResourceMemory}, vpas[0].ControlledResources())
+	assert.Len(t, parsed.Metas(), 1)
+}
+
+func TestParseVerticalPodAutoscalerInvalid(t *testing.T) {
+	doc := `apiVersion: autoscaling.k8s.io/v1
+kind: VerticalPodAutoscaler
+metadata:
+  name: foo
+spec:
+  targetRef: foo`
+
+	parser, err := New(nil)
+	assert.NoError(t, err)
+	_, err = parser.ParseFiles([]ks.NamedReader{namedReader{Reader: strings.NewReader(doc), name: "vpa.yaml"}})
+	assert.ErrorContains(t, err, "Failed to parse autoscaling.k8s.io/v1, Kind=VerticalPodAutoscaler")
+}
+
+func TestParseHorizontalPodAutoscalerV2beta2(t *testing.T) {
+	doc := `apiVersion: autoscaling/v2beta2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: foo
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: foo
+  maxReplicas: 5
+  metrics:
+  - type: Resource
+    resource:
+      name: cpu
+      target:
+        type: Utilization
+        averageUtilization: 60
+  behavior:
+    scaleDown:
+      stabilizationWindowSeconds: 120`
+
+	parser, err := New(nil)
+	assert.NoError(t, err)
+	parsed, err := parser.ParseFiles([]ks.NamedReader{namedReader{Reader: strings.NewReader(doc), name: "hpa.yaml"}})
+	assert.NoError(t, err)
+
+	hpas := parsed.HorizontalPodAutoscalers()
+	assert.Len(t, hpas, 1)
+	assert.Equal(t, int32(5), hpas[0].MaxReplicas())
+	assert.Len(t, hpas[0].Metrics(), 1)
+	assert.Equal(t, autoscalingv2.UtilizationMetricType, hpas[0].Metrics()[0].Resource.Target.Type)
+	assert.Equal(t, int32(60), *hpas[0].Metrics()[0].Resource.Target.AverageUtilization)
+	assert.Equal(t, int32(120), *hpas[0].Behavior().ScaleDown.StabilizationWindowSeconds)
+}
+
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+func (n namedReader) Name() string {
+	return n.name
+}
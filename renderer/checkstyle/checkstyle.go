@@ -0,0 +1,96 @@
+// Package checkstyle is currently considered to be in alpha status, and is not covered
+// by the API stability guarantees
+package checkstyle
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"sort"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+type checkstyle struct {
+	XMLName xml.Name `xml:"checkstyle"`
+	Version string   `xml:"version,attr"`
+	Files   []*file  `xml:"file"`
+}
+
+type file struct {
+	Name   string      `xml:"name,attr"`
+	Errors []fileError `xml:"error"`
+}
+
+type fileError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Checkstyle renders the warnings and criticals in the scorecard as a Checkstyle XML report, grouped by file
+func Checkstyle(scoreCard *scorecard.Scorecard) io.Reader {
+	var keys []string
+	for k := range *scoreCard {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	files := make(map[string]*file)
+
+	for _, key := range keys {
+		scoredObject := (*scoreCard)[key]
+
+		for _, card := range scoredObject.Checks {
+			if card.Skipped || card.Grade > scorecard.GradeWarning {
+				continue
+			}
+
+			severity := "warning"
+			if card.Grade <= scorecard.GradeCritical {
+				severity = "error"
+			}
+
+			comments := card.Comments
+			if len(comments) == 0 {
+				comments = []scorecard.TestScoreComment{{Summary: card.Check.Name}}
+			}
+
+			f, ok := files[scoredObject.FileLocation.Name]
+			if !ok {
+				f = &file{Name: scoredObject.FileLocation.Name}
+				files[scoredObject.FileLocation.Name] = f
+			}
+
+			for _, comment := range comments {
+				message := scoredObject.HumanFriendlyRef() + ": "
+				if comment.Path != "" {
+					message += "(" + comment.Path + ") "
+				}
+				message += comment.Summary
+
+				f.Errors = append(f.Errors, fileError{
+					Line:     scoredObject.FileLocation.Line,
+					Severity: severity,
+					Message:  message,
+					Source:   "kube-score." + card.Check.ID,
+				})
+			}
+		}
+	}
+
+	report := checkstyle{Version: "4.3"}
+	for _, f := range files {
+		report.Files = append(report.Files, f)
+	}
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].Name < report.Files[j].Name
+	})
+
+	w := bytes.NewBufferString(xml.Header)
+	d, _ := xml.MarshalIndent(report, "", "    ")
+	w.Write(d)
+	w.WriteString("\n")
+	return w
+}
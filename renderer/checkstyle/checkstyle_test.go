@@ -0,0 +1,75 @@
+package checkstyle
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getTestCard() *scorecard.Scorecard {
+	checks := []scorecard.TestScore{
+		{
+			Check: domain.Check{Name: "Test Critical", ID: "test-critical"},
+			Grade: scorecard.GradeCritical,
+			Comments: []scorecard.TestScoreComment{
+				{Path: "a", Summary: "summary & <more>"},
+			},
+		},
+		{
+			Check: domain.Check{Name: "Test Warning", ID: "test-warning"},
+			Grade: scorecard.GradeWarning,
+		},
+		{
+			Check:    domain.Check{Name: "Test OK", ID: "test-ok"},
+			Grade:    scorecard.GradeAllOK,
+			Comments: []scorecard.TestScoreComment{{Summary: "ok"}},
+		},
+		{
+			Check:    domain.Check{Name: "Test Skipped", ID: "test-skipped"},
+			Skipped:  true,
+			Comments: []scorecard.TestScoreComment{{Summary: "skipped"}},
+		},
+	}
+
+	return &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: "foo", Namespace: "foofoo"},
+			FileLocation: domain.FileLocation{Name: "foo.yaml", Line: 3},
+			Checks:       checks,
+		},
+		"b": &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: "bar"},
+			FileLocation: domain.FileLocation{Name: "bar.yaml", Line: 1},
+			Checks:       checks[1:2],
+		},
+		"c": &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: "ok"},
+			FileLocation: domain.FileLocation{Name: "ok.yaml", Line: 1},
+			Checks:       checks[2:],
+		},
+	}
+}
+
+func TestCheckstyleOutput(t *testing.T) {
+	t.Parallel()
+	all, err := io.ReadAll(Checkstyle(getTestCard()))
+	assert.Nil(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+    <file name="bar.yaml">
+        <error line="1" severity="warning" message="bar v1/Service: Test Warning" source="kube-score.test-warning"></error>
+    </file>
+    <file name="foo.yaml">
+        <error line="3" severity="error" message="foo/foofoo apps/v1/Deployment: (a) summary &amp; &lt;more&gt;" source="kube-score.test-critical"></error>
+        <error line="3" severity="warning" message="foo/foofoo apps/v1/Deployment: Test Warning" source="kube-score.test-warning"></error>
+    </file>
+</checkstyle>
+`, string(all))
+}
@@ -61,5 +61,7 @@ func CI(scoreCard *scorecard.Scorecard) io.Reader {
 		}
 	}
 
+	fmt.Fprintf(w, "[SUMMARY] %s\n", scoreCard.Summary().Overall)
+
 	return w
 }
@@ -107,5 +107,6 @@ func TestCiOutput(t *testing.T) {
 [OK] bar-no-namespace v1/Testing: (a) summary
 [SKIPPED] bar-no-namespace v1/Testing: (a) skipped sum
 [SKIPPED] bar-no-namespace v1/Testing
+[SUMMARY] Score: 72/100, 0 critical, 2 warning, 2 ok, 4 skipped
 `, string(all))
 }
@@ -0,0 +1,97 @@
+// Package github is currently considered to be in alpha status, and is not covered
+// by the API stability guarantees
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/zegl/kube-score/renderer/markdown"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// stepSummaryMaxLength is the size limit of a job summary, larger summaries are not shown by GitHub
+const stepSummaryMaxLength = 1024 * 1024
+
+// Output renders the scorecard as GitHub Actions workflow commands, that are shown as annotations on the lines of the
+// files that have problems. The annotations are placed on the line of the path of each comment when it's known.
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+func Output(scoreCard *scorecard.Scorecard) io.Reader {
+	var keys []string
+	for k := range *scoreCard {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	w := bytes.NewBufferString("")
+
+	for _, key := range keys {
+		scoredObject := (*scoreCard)[key]
+
+		for _, card := range scoredObject.Checks {
+			if card.Skipped {
+				continue
+			}
+
+			var command string
+			switch {
+			case card.Grade <= scorecard.GradeCritical:
+				command = "error"
+			case card.Grade <= scorecard.GradeWarning:
+				command = "warning"
+			default:
+				continue
+			}
+
+			for _, comment := range card.Comments {
+				title := card.Check.Name
+				if comment.Path != "" {
+					title += " (" + comment.Path + ")"
+				}
+
+				var properties []string
+				if location := scoredObject.CommentLocation(comment); location.Name != "" {
+					properties = append(properties, "file="+escapeProperty(location.Name))
+					if location.Line > 0 {
+						properties = append(properties, fmt.Sprintf("line=%d", location.Line))
+					}
+				}
+				properties = append(properties, "title="+escapeProperty(title))
+
+				message := scoredObject.HumanFriendlyRef() + ": " + comment.Summary
+				if comment.Description != "" {
+					message += "\n" + comment.Description
+				}
+				if comment.DocumentationURL != "" {
+					message += "\nMore information: " + comment.DocumentationURL
+				}
+
+				_, _ = fmt.Fprintf(w, "::%s %s::%s\n", command, strings.Join(properties, ","), escapeData(message))
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "::notice title=kube-score::%s\n", escapeData(scoreCard.Summary().Overall.String()))
+
+	return w
+}
+
+// StepSummary renders the scorecard as Markdown for the job summary, that is written to the file in
+// $GITHUB_STEP_SUMMARY
+func StepSummary(scoreCard *scorecard.Scorecard, verbose int) io.Reader {
+	return markdown.Markdown(scoreCard, markdown.Options{
+		Verbose:   verbose,
+		MaxLength: stepSummaryMaxLength,
+	})
+}
+
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,131 @@
+package github
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getTestCard() *scorecard.Scorecard {
+	checks := []scorecard.TestScore{
+		{
+			Check: domain.Check{
+				Name: "test-critical",
+			},
+			Grade: scorecard.GradeCritical,
+			Comments: []scorecard.TestScoreComment{
+				{
+					Path:             "a",
+					Summary:          "summary",
+					Description:      "100% broken\nreally",
+					DocumentationURL: "https://kube-score.com/",
+				},
+			},
+		},
+		{
+			Check: domain.Check{
+				Name: "test-warning",
+			},
+			Grade: scorecard.GradeWarning,
+			Comments: []scorecard.TestScoreComment{
+				{
+					// No path
+					Summary: "summary",
+				},
+			},
+		},
+		{
+			Check: domain.Check{
+				Name: "test-ok-comment",
+			},
+			Grade: scorecard.GradeAllOK,
+			Comments: []scorecard.TestScoreComment{
+				{
+					Summary: "summary",
+				},
+			},
+		},
+		{
+			Check: domain.Check{
+				Name: "test-skipped-comment",
+			},
+			Skipped: true,
+			Comments: []scorecard.TestScoreComment{
+				{
+					Summary: "skipped sum",
+				},
+			},
+		},
+	}
+
+	return &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta: v1.TypeMeta{
+				Kind:       "Testing",
+				APIVersion: "v1",
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "foofoo",
+			},
+			FileLocation: domain.FileLocation{Name: "dir/foo,bar.yaml", Line: 7},
+			Checks:       checks,
+		},
+
+		// No namespace or file
+		"b": &scorecard.ScoredObject{
+			TypeMeta: v1.TypeMeta{
+				Kind:       "Testing",
+				APIVersion: "v1",
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name: "bar-no-namespace",
+			},
+			Checks: checks[1:],
+		},
+	}
+}
+
+func TestGithubOutput(t *testing.T) {
+	t.Parallel()
+	all, err := io.ReadAll(Output(getTestCard()))
+	assert.Nil(t, err)
+	assert.Equal(t, `::error file=dir/foo%2Cbar.yaml,line=7,title=test-critical (a)::foo/foofoo v1/Testing: summary%0A100%25 broken%0Areally%0AMore information: https://kube-score.com/
+::warning file=dir/foo%2Cbar.yaml,line=7,title=test-warning::foo/foofoo v1/Testing: summary
+::warning title=test-warning::bar-no-namespace v1/Testing: summary
+::notice title=kube-score::Score: 58/100, 1 critical, 2 warning, 2 ok, 2 skipped
+`, string(all))
+}
+
+type testLines map[string]int
+
+func (l testLines) Line(path string) (int, bool) {
+	line, ok := l[path]
+	return line, ok
+}
+
+func TestGithubOutputCommentLocation(t *testing.T) {
+	t.Parallel()
+	card := getTestCard()
+	(*card)["a"].FileLocation.Lines = testLines{"a": 12}
+	all, err := io.ReadAll(Output(card))
+	assert.Nil(t, err)
+	assert.Equal(t, `::error file=dir/foo%2Cbar.yaml,line=12,title=test-critical (a)::foo/foofoo v1/Testing: summary%0A100%25 broken%0Areally%0AMore information: https://kube-score.com/
+::warning file=dir/foo%2Cbar.yaml,line=7,title=test-warning::foo/foofoo v1/Testing: summary
+::warning title=test-warning::bar-no-namespace v1/Testing: summary
+::notice title=kube-score::Score: 58/100, 1 critical, 2 warning, 2 ok, 2 skipped
+`, string(all))
+}
+
+func TestGithubStepSummary(t *testing.T) {
+	t.Parallel()
+	all, err := io.ReadAll(StepSummary(getTestCard(), 0))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(all), "## kube-score\n"))
+	assert.Contains(t, string(all), "| Critical | 1 |")
+}
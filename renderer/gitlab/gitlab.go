@@ -0,0 +1,92 @@
+// Package gitlab is currently considered to be in alpha status, and is not covered
+// by the API stability guarantees
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// Issue is a finding in the GitLab Code Quality report format
+// See https://docs.gitlab.com/ee/ci/testing/code_quality.html#implement-a-custom-tool
+type Issue struct {
+	Description string   `json:"description"`
+	CheckName   string   `json:"check_name"`
+	Fingerprint string   `json:"fingerprint"`
+	Severity    string   `json:"severity"`
+	Location    Location `json:"location"`
+}
+
+type Location struct {
+	Path  string `json:"path"`
+	Lines Lines  `json:"lines"`
+}
+
+type Lines struct {
+	Begin int `json:"begin"`
+}
+
+// CodeQuality renders the warnings and criticals in the scorecard as a GitLab Code Quality report
+func CodeQuality(scoreCard *scorecard.Scorecard) io.Reader {
+	var keys []string
+	for k := range *scoreCard {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	issues := make([]Issue, 0)
+
+	for _, key := range keys {
+		scoredObject := (*scoreCard)[key]
+
+		for _, card := range scoredObject.Checks {
+			if card.Skipped || card.Grade > scorecard.GradeWarning {
+				continue
+			}
+
+			severity := "major"
+			if card.Grade <= scorecard.GradeCritical {
+				severity = "critical"
+			}
+
+			comments := card.Comments
+			if len(comments) == 0 {
+				comments = []scorecard.TestScoreComment{{Summary: card.Check.Name}}
+			}
+
+			// GitLab merges issues with the same fingerprint, comments with the same path are kept apart by their summary
+			fingerprints := scoredObject.CommentFingerprints(card.Check.ID, comments)
+			for i, comment := range comments {
+				description := scoredObject.HumanFriendlyRef() + ": "
+				if comment.Path != "" {
+					description += "(" + comment.Path + ") "
+				}
+				description += comment.Summary
+
+				// GitLab requires a line number
+				line := scoredObject.FileLocation.Line
+				if line < 1 {
+					line = 1
+				}
+
+				issues = append(issues, Issue{
+					Description: description,
+					CheckName:   card.Check.ID,
+					Fingerprint: fingerprints[i],
+					Severity:    severity,
+					Location: Location{
+						Path:  scoredObject.FileLocation.Name,
+						Lines: Lines{Begin: line},
+					},
+				})
+			}
+		}
+	}
+
+	d, _ := json.MarshalIndent(issues, "", "    ")
+	return bytes.NewReader(d)
+}
@@ -0,0 +1,116 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getTestCard(apiVersion string) *scorecard.Scorecard {
+	checks := []scorecard.TestScore{
+		{
+			Check: domain.Check{Name: "Test Critical", ID: "test-critical"},
+			Grade: scorecard.GradeCritical,
+			Comments: []scorecard.TestScoreComment{
+				{Path: "a", Summary: "summary a"},
+				{Path: "b", Summary: "summary b"},
+				{Path: "b", Summary: "another summary b"},
+			},
+		},
+		{
+			Check: domain.Check{Name: "Test Warning", ID: "test-warning"},
+			Grade: scorecard.GradeWarning,
+		},
+		{
+			Check:    domain.Check{Name: "Test OK", ID: "test-ok"},
+			Grade:    scorecard.GradeAllOK,
+			Comments: []scorecard.TestScoreComment{{Summary: "ok"}},
+		},
+		{
+			Check:    domain.Check{Name: "Test Skipped", ID: "test-skipped"},
+			Skipped:  true,
+			Comments: []scorecard.TestScoreComment{{Summary: "skipped"}},
+		},
+	}
+
+	return &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Deployment", APIVersion: apiVersion},
+			ObjectMeta:   v1.ObjectMeta{Name: "foo", Namespace: "foofoo"},
+			FileLocation: domain.FileLocation{Name: "foo.yaml", Line: 3},
+			Checks:       checks,
+		},
+	}
+}
+
+func readIssues(t *testing.T, r io.Reader) []Issue {
+	var issues []Issue
+	all, err := io.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(all, &issues))
+	return issues
+}
+
+func TestCodeQualityOutput(t *testing.T) {
+	t.Parallel()
+	issues := readIssues(t, CodeQuality(getTestCard("apps/v1")))
+	assert.Len(t, issues, 4)
+
+	assert.Equal(t, "foo/foofoo apps/v1/Deployment: (a) summary a", issues[0].Description)
+	assert.Equal(t, "test-critical", issues[0].CheckName)
+	assert.Equal(t, "critical", issues[0].Severity)
+	assert.Equal(t, Location{Path: "foo.yaml", Lines: Lines{Begin: 3}}, issues[0].Location)
+
+	// Checks without comments are reported with the check name
+	assert.Equal(t, "foo/foofoo apps/v1/Deployment: Test Warning", issues[3].Description)
+	assert.Equal(t, "major", issues[3].Severity)
+
+	// All fingerprints are unique, also for comments with the same path
+	fingerprints := make(map[string]struct{})
+	for _, issue := range issues {
+		fingerprints[issue.Fingerprint] = struct{}{}
+	}
+	assert.Len(t, fingerprints, 4)
+}
+
+func TestCodeQualityStableFingerprints(t *testing.T) {
+	t.Parallel()
+	before := readIssues(t, CodeQuality(getTestCard("apps/v1beta1")))
+
+	after := getTestCard("apps/v1")
+	(*after)["a"].FileLocation = domain.FileLocation{Name: "moved.yaml", Line: 30}
+	(*after)["a"].Checks[0].Comments[0].Summary = "reworded"
+
+	for i, issue := range readIssues(t, CodeQuality(after)) {
+		assert.Equal(t, before[i].Fingerprint, issue.Fingerprint)
+	}
+}
+
+func TestCodeQualityStableFingerprintsNewComment(t *testing.T) {
+	t.Parallel()
+	before := readIssues(t, CodeQuality(getTestCard("apps/v1")))
+
+	// A new comment with the same path as existing comments does not change their fingerprints
+	after := getTestCard("apps/v1")
+	comments := (*after)["a"].Checks[0].Comments
+	(*after)["a"].Checks[0].Comments = append([]scorecard.TestScoreComment{comments[0], {Path: "b", Summary: "new summary b"}}, comments[1:]...)
+
+	issues := readIssues(t, CodeQuality(after))
+	assert.Len(t, issues, 5)
+	assert.Equal(t, before[0].Fingerprint, issues[0].Fingerprint)
+	assert.Equal(t, before[1].Fingerprint, issues[2].Fingerprint)
+	assert.Equal(t, before[2].Fingerprint, issues[3].Fingerprint)
+	assert.Equal(t, before[3].Fingerprint, issues[4].Fingerprint)
+}
+
+func TestCodeQualityEmpty(t *testing.T) {
+	t.Parallel()
+	all, err := io.ReadAll(CodeQuality(&scorecard.Scorecard{}))
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(all))
+}
@@ -0,0 +1,178 @@
+// Package html is currently considered to be in alpha status, and is not covered
+// by the API stability guarantees
+package html
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/renderer/internal"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+var tmpl = template.Must(template.New("report").Parse(reportTemplate))
+
+type report struct {
+	Summary map[string]int
+	Overall scorecard.Summary
+	Scores  []scoreRow
+	Objects []object
+	Catalog []domain.Check
+
+	// The values that can be used in the filters, Checks only contains the checks that have failed for any object
+	Kinds      []string
+	Namespaces []string
+	Checks     []domain.Check
+}
+
+type scoreRow struct {
+	Name    string
+	Summary scorecard.Summary
+}
+
+type object struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+	File       string
+	Line       int
+	Grade      string
+	Score      int
+	Problems   int
+
+	// Space-separated IDs of the checks with warnings or criticals, used to filter by check
+	FailedChecks string
+
+	Checks []check
+}
+
+type check struct {
+	ID       string
+	Name     string
+	Grade    string
+	Comments []scorecard.TestScoreComment
+}
+
+func gradeClass(g scorecard.Grade) string {
+	switch {
+	case g <= scorecard.GradeCritical:
+		return "critical"
+	case g <= scorecard.GradeWarning:
+		return "warning"
+	default:
+		return "ok"
+	}
+}
+
+func newObject(so *scorecard.ScoredObject) object {
+	o := object{
+		APIVersion: so.TypeMeta.APIVersion,
+		Kind:       so.TypeMeta.Kind,
+		Name:       so.ObjectMeta.Name,
+		Namespace:  so.ObjectMeta.Namespace,
+		File:       so.FileLocation.Name,
+		Line:       so.FileLocation.Line,
+		Grade:      "ok",
+		Score:      so.Summary().Score,
+	}
+
+	worst := scorecard.GradeAllOK
+	for _, c := range so.Checks {
+		grade := "skipped"
+		if !c.Skipped {
+			grade = gradeClass(c.Grade)
+			if c.Grade < worst {
+				worst = c.Grade
+			}
+			if c.Grade <= scorecard.GradeWarning {
+				o.Problems++
+				if o.FailedChecks != "" {
+					o.FailedChecks += " "
+				}
+				o.FailedChecks += c.Check.ID
+			}
+		}
+		o.Checks = append(o.Checks, check{
+			ID:       c.Check.ID,
+			Name:     c.Check.Name,
+			Grade:    grade,
+			Comments: c.Comments,
+		})
+	}
+	o.Grade = gradeClass(worst)
+
+	return o
+}
+
+// HTML renders the scorecard as a single self-contained HTML page, with an appendix describing all checks
+func HTML(scoreCard *scorecard.Scorecard, allChecks []domain.Check) (io.Reader, error) {
+	var keys []string
+	for k := range *scoreCard {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	r := report{
+		Summary: map[string]int{"critical": 0, "warning": 0, "ok": 0},
+	}
+
+	kinds := make(map[string]struct{})
+	namespaces := make(map[string]struct{})
+	checks := make(map[string]domain.Check)
+
+	for _, key := range keys {
+		so := (*scoreCard)[key]
+		o := newObject(so)
+		r.Objects = append(r.Objects, o)
+		r.Summary[o.Grade]++
+
+		kinds[o.Kind] = struct{}{}
+		if o.Namespace != "" {
+			namespaces[o.Namespace] = struct{}{}
+		}
+		for _, c := range so.Checks {
+			if !c.Skipped && c.Grade <= scorecard.GradeWarning {
+				checks[c.Check.ID] = c.Check
+			}
+		}
+	}
+
+	summary := scoreCard.Summary()
+	r.Overall = summary.Overall
+	for _, ns := range internal.SortedKeys(summary.Namespaces) {
+		name := "Namespace " + ns
+		if ns == "" {
+			name = "No namespace"
+		}
+		r.Scores = append(r.Scores, scoreRow{Name: name, Summary: summary.Namespaces[ns]})
+	}
+	for _, kind := range internal.SortedKeys(summary.Kinds) {
+		r.Scores = append(r.Scores, scoreRow{Name: "Kind " + kind, Summary: summary.Kinds[kind]})
+	}
+
+	r.Kinds = internal.SortedKeys(kinds)
+	r.Namespaces = internal.SortedKeys(namespaces)
+	for _, id := range internal.SortedKeys(checks) {
+		r.Checks = append(r.Checks, checks[id])
+	}
+
+	r.Catalog = append(r.Catalog, allChecks...)
+	sort.Slice(r.Catalog, func(i, j int) bool {
+		return r.Catalog[i].ID < r.Catalog[j].ID
+	})
+
+	w := bytes.NewBufferString("")
+	if err := tmpl.Execute(w, r); err != nil {
+		return nil, fmt.Errorf("failed to render html: %w", err)
+	}
+	return w, nil
+}
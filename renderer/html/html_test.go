@@ -0,0 +1,102 @@
+package html
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getTestCard() *scorecard.Scorecard {
+	checks := []scorecard.TestScore{
+		{
+			Check: domain.Check{
+				Name: "Test Warning",
+				ID:   "test-warning",
+			},
+			Grade: scorecard.GradeWarning,
+			Comments: []scorecard.TestScoreComment{
+				{
+					Path:             "a",
+					Summary:          "summary <script>",
+					Description:      "description",
+					DocumentationURL: "https://kube-score.com/",
+				},
+			},
+		},
+		{
+			Check: domain.Check{
+				Name: "Test OK",
+				ID:   "test-ok",
+			},
+			Grade: scorecard.GradeAllOK,
+		},
+		{
+			Check: domain.Check{
+				Name: "Test Skipped",
+				ID:   "test-skipped",
+			},
+			Skipped: true,
+		},
+	}
+
+	return &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: "foo", Namespace: "foofoo"},
+			FileLocation: domain.FileLocation{Name: "foo.yaml", Line: 3},
+			Checks:       checks,
+		},
+		"b": &scorecard.ScoredObject{
+			TypeMeta:   v1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: v1.ObjectMeta{Name: "bar"},
+			Checks:     checks[1:],
+		},
+	}
+}
+
+func TestHTMLOutput(t *testing.T) {
+	t.Parallel()
+	catalog := []domain.Check{
+		{Name: "Test Warning", ID: "test-warning", TargetType: "Deployment", Comment: "Warns about things"},
+		{Name: "Test Optional", ID: "test-optional", TargetType: "Pod", Comment: "Not enabled by default", Optional: true},
+	}
+
+	r, err := HTML(getTestCard(), catalog)
+	assert.Nil(t, err)
+	all, err := io.ReadAll(r)
+	assert.Nil(t, err)
+	out := string(all)
+
+	// Summary
+	assert.Contains(t, out, `Score: <b>81/100</b> (0 critical, 1 warning, 2 ok and 2 skipped checks)`)
+	assert.Contains(t, out, `<tr><td>Namespace foofoo</td><td>72</td><td>0</td><td>1</td><td>1</td><td>1</td></tr>`)
+	assert.Contains(t, out, `<tr><td>No namespace</td><td>100</td><td>0</td><td>0</td><td>1</td><td>1</td></tr>`)
+	assert.Contains(t, out, `<tr><td>Kind Service</td><td>100</td><td>0</td><td>0</td><td>1</td><td>1</td></tr>`)
+	assert.Contains(t, out, `<span class="grade warning">WARNING</span> 1 objects`)
+	assert.Contains(t, out, `<span class="grade ok">OK</span> 1 objects`)
+
+	// Filters
+	assert.Contains(t, out, `<option value="Deployment">Deployment</option>`)
+	assert.Contains(t, out, `<option value="Service">Service</option>`)
+	assert.Contains(t, out, `<option value="foofoo">foofoo</option>`)
+	assert.Contains(t, out, `<option value="test-warning">Test Warning</option>`)
+	assert.NotContains(t, out, `<option value="test-ok">`)
+
+	// Objects
+	assert.Contains(t, out, `data-grade="warning" data-kind="Deployment" data-namespace="foofoo" data-name="foo" data-score="72" data-problems="1" data-file="foo.yaml" data-checks="test-warning"`)
+	assert.Contains(t, out, `data-grade="ok" data-kind="Service" data-namespace="" data-name="bar" data-score="100" data-problems="0" data-file="" data-checks=""`)
+	assert.Contains(t, out, `<code>foo.yaml:3</code>`)
+	assert.Contains(t, out, `<div class="check skipped">`)
+
+	// Comments are escaped
+	assert.Contains(t, out, `summary &lt;script&gt;`)
+	assert.Contains(t, out, `<a href="https://kube-score.com/">More information</a>`)
+
+	// Catalog
+	assert.Contains(t, out, `<tr id="check-test-optional"><td><code>test-optional</code></td><td>Pod</td><td>Not enabled by default</td><td>Yes</td></tr>`)
+	assert.Contains(t, out, `<tr id="check-test-warning"><td><code>test-warning</code></td><td>Deployment</td><td>Warns about things</td><td>No</td></tr>`)
+}
@@ -0,0 +1,85 @@
+package human
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// Diff renders the findings that were introduced and resolved in each object. Objects without changes, and the
+// unchanged findings, are included if verboseOutput is at least 1.
+func Diff(diff scorecard.ScorecardDiff, verboseOutput int, termWidth int, useColors bool) (io.Reader, error) {
+	// Override usage of colors to our own preference
+	color.NoColor = !useColors
+
+	w := bytes.NewBufferString("")
+
+	for _, d := range diff.Objects {
+		if !d.Changed() && verboseOutput < 1 {
+			continue
+		}
+
+		o := d.Object()
+
+		// Headers for each object
+		var writtenHeaderChars int
+		writtenHeaderChars, _ = color.New(color.FgMagenta).Fprintf(w, "%s/%s %s", o.TypeMeta.APIVersion, o.TypeMeta.Kind, o.ObjectMeta.Name)
+		if o.ObjectMeta.Namespace != "" {
+			written2, _ := color.New(color.FgMagenta).Fprintf(w, " in %s", o.ObjectMeta.Namespace)
+			writtenHeaderChars += written2
+		}
+
+		var status string
+		switch {
+		case d.Old == nil:
+			status = fmt.Sprintf("added, score %d", d.New.Summary().Score)
+		case d.New == nil:
+			status = "removed"
+		default:
+			status = fmt.Sprintf("score %d -> %d", d.Old.Summary().Score, d.New.Summary().Score)
+		}
+
+		// Adjust to termsize
+		_, err := fmt.Fprintf(w, "%s%s\n", safeRepeat(" ", min(80, termWidth)-writtenHeaderChars-len(status)), status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write: %w", err)
+		}
+
+		if d.Changed() && o.FileLocation.Name != "" {
+			_, _ = color.New(color.FgHiBlack).Fprintf(w, "    path=%s\n", o.FileLocation.Name)
+		}
+
+		writeFindings(w, "+", color.FgRed, d.Introduced)
+		writeFindings(w, "-", color.FgGreen, d.Resolved)
+		if verboseOutput >= 1 {
+			writeFindings(w, "=", color.FgHiBlack, d.Unchanged)
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d introduced, %d resolved, %d unchanged\nScore: %d/100 -> %d/100\n",
+		diff.Introduced, diff.Resolved, diff.Unchanged, diff.Old.Score, diff.New.Score)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write summary: %w", err)
+	}
+
+	return w, nil
+}
+
+func writeFindings(w io.Writer, prefix string, col color.Attribute, findings []scorecard.Finding) {
+	for _, f := range findings {
+		_, _ = color.New(col).Fprintf(w, "    %s [%s] %s\n", prefix, f.Grade.String(), f.Check.Name)
+
+		if f.Comment.Summary == "" {
+			continue
+		}
+		_, _ = fmt.Fprint(w, "        · ")
+		if len(f.Comment.Path) > 0 {
+			_, _ = fmt.Fprintf(w, "%s -> ", f.Comment.Path)
+		}
+		_, _ = fmt.Fprintln(w, f.Comment.Summary)
+	}
+}
@@ -0,0 +1,91 @@
+package human
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func getTestDiff() scorecard.ScorecardDiff {
+	object := func(name string, checks ...scorecard.TestScore) *scorecard.ScoredObject {
+		return &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: name, Namespace: "foofoo"},
+			FileLocation: domain.FileLocation{Name: name + ".yaml", Line: 1},
+			Checks:       checks,
+		}
+	}
+	check := func(id string, grade scorecard.Grade, comments ...scorecard.TestScoreComment) scorecard.TestScore {
+		return scorecard.TestScore{Check: domain.Check{ID: id, Name: id}, Grade: grade, Comments: comments}
+	}
+
+	old := scorecard.Scorecard{
+		"a": object("a",
+			check("test-critical", scorecard.GradeCritical, scorecard.TestScoreComment{Path: "x", Summary: "critical summary"}),
+			check("test-warning", scorecard.GradeWarning),
+		),
+		"b": object("b", check("test-warning", scorecard.GradeWarning)),
+		"c": object("c", check("test-warning", scorecard.GradeWarning)),
+	}
+	new := scorecard.Scorecard{
+		"a": object("a",
+			check("test-critical", scorecard.GradeCritical, scorecard.TestScoreComment{Path: "y", Summary: "critical summary"}),
+			check("test-warning", scorecard.GradeWarning),
+		),
+		"b": object("b", check("test-warning", scorecard.GradeWarning)),
+		"d": object("d", check("test-warning", scorecard.GradeAllOK)),
+	}
+
+	return scorecard.Diff(old, new)
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	r, err := Diff(getTestDiff(), 0, 100, false)
+	assert.Nil(t, err)
+	all, err := io.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, `apps/v1/Deployment a in foofoo                                    score 22 -> 22
+    path=a.yaml
+    + [CRITICAL] test-critical
+        · y -> critical summary
+    - [CRITICAL] test-critical
+        · x -> critical summary
+apps/v1/Deployment c in foofoo                                           removed
+    path=c.yaml
+    - [WARNING] test-warning
+
+1 introduced, 2 resolved, 2 unchanged
+Score: 33/100 -> 47/100
+`, string(all))
+}
+
+func TestDiffVerbose(t *testing.T) {
+	t.Parallel()
+	r, err := Diff(getTestDiff(), 1, 100, false)
+	assert.Nil(t, err)
+	all, err := io.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Equal(t, `apps/v1/Deployment a in foofoo                                    score 22 -> 22
+    path=a.yaml
+    + [CRITICAL] test-critical
+        · y -> critical summary
+    - [CRITICAL] test-critical
+        · x -> critical summary
+    = [WARNING] test-warning
+apps/v1/Deployment b in foofoo                                    score 44 -> 44
+    = [WARNING] test-warning
+apps/v1/Deployment c in foofoo                                           removed
+    path=c.yaml
+    - [WARNING] test-warning
+apps/v1/Deployment d in foofoo                                  added, score 100
+
+1 introduced, 2 resolved, 2 unchanged
+Score: 33/100 -> 47/100
+`, string(all))
+}
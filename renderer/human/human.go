@@ -64,6 +64,10 @@ func Human(scoreCard *scorecard.Scorecard, verboseOutput int, termWidth int, use
 			}
 		}
 
+		if scoredObject.QOSClass != "" {
+			_, _ = color.New(color.FgHiBlack).Fprintf(w, "    qos=%s\n", scoredObject.QOSClass)
+		}
+
 		for _, card := range scoredObject.Checks {
 			r := outputHumanStep(card, verboseOutput, termWidth)
 			if _, err := io.Copy(w, r); err != nil {
@@ -72,6 +76,11 @@ func Human(scoreCard *scorecard.Scorecard, verboseOutput int, termWidth int, use
 		}
 	}
 
+	_, err := fmt.Fprintf(w, "\n%s\n", scoreCard.Summary().Overall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write summary: %w", err)
+	}
+
 	return w, nil
 }
 
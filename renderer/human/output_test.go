@@ -113,6 +113,8 @@ v1/Testing bar-no-namespace                                                   
         · summary
             description
             More information: https://kube-score.com/whatever
+
+Score: 72/100, 0 critical, 2 warning, 2 ok, 4 skipped
 `, string(all))
 }
 
@@ -142,6 +144,8 @@ v1/Testing bar-no-namespace                                                   
     [OK] test-ok-comment
         · a -> summary
             description
+
+Score: 72/100, 0 critical, 2 warning, 2 ok, 4 skipped
 `, string(all))
 }
 
@@ -179,6 +183,8 @@ v1/Testing bar-no-namespace                                                   
         · a -> skipped sum
             skipped description
     [SKIPPED] test-skipped-no-comment
+
+Score: 72/100, 0 critical, 2 warning, 2 ok, 4 skipped
 `, string(all))
 }
 
@@ -271,6 +277,8 @@ func TestHumanOutputAllOKDefault(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, `v1/Testing foo in foofoo                                                      ✅
 v1/Testing bar-no-namespace                                                   ✅
+
+Score: 100/100, 0 critical, 0 warning, 4 ok, 4 skipped
 `, string(all))
 }
 
@@ -319,6 +327,8 @@ func TestHumanOutputLogDescription120Width(t *testing.T) {
             lobortis vel. Pellentesque habitant morbi tristique senectus et netus et malesuada fames ac turpis egestas.
             Nulla eu neque erat. Vestibulum ante ipsum primis in faucibus orci luctus et ultrices posuere cubilia Curae;
             Maecenas et nisl venenatis, elementum augue a, porttitor libero.
+
+Score: 44/100, 0 critical, 1 warning, 0 ok, 0 skipped
 `, string(all))
 }
 
@@ -336,6 +346,8 @@ func TestHumanOutputLogDescription100Width(t *testing.T) {
             malesuada fames ac turpis egestas. Nulla eu neque erat. Vestibulum ante ipsum primis in
             faucibus orci luctus et ultrices posuere cubilia Curae; Maecenas et nisl venenatis,
             elementum augue a, porttitor libero.
+
+Score: 44/100, 0 critical, 1 warning, 0 ok, 0 skipped
 `, string(all))
 }
 
@@ -354,6 +366,8 @@ func TestHumanOutputLogDescription80Width(t *testing.T) {
             turpis egestas. Nulla eu neque erat. Vestibulum ante ipsum primis in
             faucibus orci luctus et ultrices posuere cubilia Curae; Maecenas et
             nisl venenatis, elementum augue a, porttitor libero.
+
+Score: 44/100, 0 critical, 1 warning, 0 ok, 0 skipped
 `, string(all))
 }
 
@@ -376,6 +390,8 @@ func TestHumanOutputLogDescription0Width(t *testing.T) {
             orci luctus et ultrices posuere cubilia
             Curae; Maecenas et nisl venenatis,
             elementum augue a, porttitor libero.
+
+Score: 44/100, 0 critical, 1 warning, 0 ok, 0 skipped
 `, string(all))
 }
 
@@ -426,5 +442,21 @@ func TestHumanOutputWithLongObjectNames(t *testing.T) {
             turpis egestas. Nulla eu neque erat. Vestibulum ante ipsum primis in
             faucibus orci luctus et ultrices posuere cubilia Curae; Maecenas et
             nisl venenatis, elementum augue a, porttitor libero.
+
+Score: 44/100, 0 critical, 1 warning, 0 ok, 0 skipped
 `, string(all))
 }
+
+func TestHumanOutputQOSClass(t *testing.T) {
+	t.Parallel()
+	card := getTestCardLongDescription()
+	(*card)["a"].QOSClass = "Burstable"
+	r, err := Human(card, 0, 100, false)
+	assert.Nil(t, err)
+	all, err := io.ReadAll(r)
+	assert.Nil(t, err)
+	assert.Contains(t, string(all), `v1/Testing foo in foofoo                                                      🤔
+    qos=Burstable
+    [WARNING] test-warning-two-comments
+`)
+}
@@ -0,0 +1,14 @@
+// Package internal has helpers that are shared by the renderers
+package internal
+
+import "sort"
+
+// SortedKeys returns the keys of a map in sorted order, for output that is the same between runs
+func SortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -26,6 +26,7 @@ type ScoredObject struct {
 	Checks     []TestScore       `json:"checks"`
 	FileName   string            `json:"file_name"`
 	FileRow    int               `json:"file_row"`
+	QOSClass   string            `json:"qos_class,omitempty"`
 }
 
 type TestScore struct {
@@ -52,6 +53,7 @@ func Output(input *scorecard.Scorecard) io.Reader {
 			Checks:     convertTestScore(v.Checks),
 			FileName:   v.FileLocation.Name,
 			FileRow:    v.FileLocation.Line,
+			QOSClass:   string(v.QOSClass),
 		})
 	}
 
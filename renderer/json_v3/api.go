@@ -0,0 +1,280 @@
+// Package json_v3 is the "v3" version of the json output format. The format is described by the JSON Schema in
+// schema.json.
+package json_v3
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/renderer/internal"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// Schema is the JSON Schema of the output
+//
+//go:embed schema.json
+var Schema []byte
+
+const formatVersion = "v3"
+
+type Report struct {
+	Version           string         `json:"version"`
+	Tool              Tool           `json:"tool"`
+	KubernetesVersion string         `json:"kubernetes_version"`
+	Config            Config         `json:"config"`
+	Summary           Summary        `json:"summary"`
+	Objects           []ScoredObject `json:"objects"`
+}
+
+type Tool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type Config struct {
+	EnabledOptionalTests        []string `json:"enabled_optional_tests"`
+	IgnoredTests                []string `json:"ignored_tests"`
+	UseIgnoreChecksAnnotation   bool     `json:"use_ignore_checks_annotation"`
+	UseOptionalChecksAnnotation bool     `json:"use_optional_checks_annotation"`
+}
+
+// Summary has the number of objects with each grade, the number of check results with each grade, and the score of
+// all objects, and of the objects in each namespace and of each kind
+type Summary struct {
+	Score      int                     `json:"score"`
+	Objects    GradeCount              `json:"objects"`
+	Checks     GradeCount              `json:"checks"`
+	Namespaces map[string]ScoreSummary `json:"namespaces"`
+	Kinds      map[string]ScoreSummary `json:"kinds"`
+}
+
+type ScoreSummary struct {
+	Score int `json:"score"`
+	GradeCount
+}
+
+type GradeCount struct {
+	Critical int `json:"critical"`
+	Warning  int `json:"warning"`
+	OK       int `json:"ok"`
+	Skipped  int `json:"skipped"`
+}
+
+type Check struct {
+	Name       string `json:"name"`
+	ID         string `json:"id"`
+	TargetType string `json:"target_type"`
+	Comment    string `json:"comment"`
+	Optional   bool   `json:"optional"`
+}
+
+type Location struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+type ScoredObject struct {
+	ObjectName string      `json:"object_name"`
+	Kind       string      `json:"kind"`
+	APIVersion string      `json:"api_version"`
+	Name       string      `json:"name"`
+	Namespace  string      `json:"namespace"`
+	Location   Location    `json:"location"`
+	QOSClass   string      `json:"qos_class,omitempty"`
+	Grade      string      `json:"grade"`
+	Score      int         `json:"score"`
+	Checks     []TestScore `json:"checks"`
+}
+
+type TestScore struct {
+	Check    Check              `json:"check"`
+	Grade    int                `json:"grade"`
+	Result   string             `json:"result"`
+	Skipped  bool               `json:"skipped"`
+	Ignored  bool               `json:"ignored"`
+	Comments []TestScoreComment `json:"comments"`
+}
+
+type TestScoreComment struct {
+	Path             string          `json:"path"`
+	Summary          string          `json:"summary"`
+	Description      string          `json:"description"`
+	DocumentationURL string          `json:"documentation_url"`
+	Location         CommentLocation `json:"location"`
+}
+
+// CommentLocation is the location of the comment in the file, and the path to the field in the object that the
+// comment is about. The line is the line of the field if it's known, and the line of the object otherwise.
+type CommentLocation struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Path string `json:"path"`
+}
+
+// Options has information about the run, that is included in the output
+type Options struct {
+	ToolVersion  string
+	Config       *config.RunConfiguration
+	IgnoredTests map[string]struct{}
+}
+
+func result(ts scorecard.TestScore) string {
+	switch {
+	case ts.Skipped:
+		return "skipped"
+	case ts.Grade <= scorecard.GradeCritical:
+		return "critical"
+	case ts.Grade <= scorecard.GradeWarning:
+		return "warning"
+	default:
+		return "ok"
+	}
+}
+
+func (c *GradeCount) add(result string) {
+	switch result {
+	case "critical":
+		c.Critical++
+	case "warning":
+		c.Warning++
+	case "ok":
+		c.OK++
+	case "skipped":
+		c.Skipped++
+	}
+}
+
+func Output(input *scorecard.Scorecard, opts Options) io.Reader {
+	cnf := opts.Config
+	if cnf == nil {
+		cnf = &config.RunConfiguration{}
+	}
+
+	report := Report{
+		Version: formatVersion,
+		Tool: Tool{
+			Name:    "kube-score",
+			Version: opts.ToolVersion,
+		},
+		KubernetesVersion: cnf.KubernetesVersion.String(),
+		Config: Config{
+			EnabledOptionalTests:        internal.SortedKeys(cnf.EnabledOptionalTests),
+			IgnoredTests:                internal.SortedKeys(opts.IgnoredTests),
+			UseIgnoreChecksAnnotation:   cnf.UseIgnoreChecksAnnotation,
+			UseOptionalChecksAnnotation: cnf.UseOptionalChecksAnnotation,
+		},
+		Objects: make([]ScoredObject, 0),
+	}
+
+	summary := input.Summary()
+	report.Summary.Score = summary.Overall.Score
+	report.Summary.Checks = gradeCount(summary.Overall)
+	report.Summary.Namespaces = scoreSummaries(summary.Namespaces)
+	report.Summary.Kinds = scoreSummaries(summary.Kinds)
+
+	for _, k := range internal.SortedKeys(*input) {
+		v := (*input)[k]
+
+		obj := ScoredObject{
+			ObjectName: k,
+			Kind:       v.TypeMeta.Kind,
+			APIVersion: v.TypeMeta.APIVersion,
+			Name:       v.ObjectMeta.Name,
+			Namespace:  v.ObjectMeta.Namespace,
+			Location:   Location{File: v.FileLocation.Name, Line: v.FileLocation.Line},
+			QOSClass:   string(v.QOSClass),
+			Grade:      "ok",
+			Score:      v.Summary().Score,
+			Checks:     convertTestScores(v),
+		}
+
+		for _, c := range obj.Checks {
+			switch {
+			case c.Result == "critical":
+				obj.Grade = "critical"
+			case c.Result == "warning" && obj.Grade == "ok":
+				obj.Grade = "warning"
+			}
+		}
+		report.Summary.Objects.add(obj.Grade)
+
+		report.Objects = append(report.Objects, obj)
+	}
+
+	j, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	return bytes.NewBuffer(j)
+}
+
+func gradeCount(s scorecard.Summary) GradeCount {
+	return GradeCount{
+		Critical: s.Critical,
+		Warning:  s.Warning,
+		OK:       s.OK,
+		Skipped:  s.Skipped,
+	}
+}
+
+func scoreSummaries(in map[string]scorecard.Summary) map[string]ScoreSummary {
+	res := make(map[string]ScoreSummary, len(in))
+	for k, v := range in {
+		res[k] = ScoreSummary{Score: v.Score, GradeCount: gradeCount(v)}
+	}
+	return res
+}
+
+// convertTestScores converts the checks, sorted by check ID
+func convertTestScores(so *scorecard.ScoredObject) []TestScore {
+	res := make([]TestScore, 0, len(so.Checks))
+	for _, v := range so.Checks {
+		res = append(res, TestScore{
+			Check:    convertCheck(v.Check),
+			Grade:    int(v.Grade),
+			Result:   result(v),
+			Skipped:  v.Skipped,
+			Ignored:  v.Ignored,
+			Comments: convertComments(so, v.Comments),
+		})
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Check.ID < res[j].Check.ID
+	})
+	return res
+}
+
+// convertComments converts the comments, with the location of the path of each comment if it's known
+func convertComments(so *scorecard.ScoredObject, in []scorecard.TestScoreComment) []TestScoreComment {
+	res := make([]TestScoreComment, 0, len(in))
+	for _, v := range in {
+		location := so.CommentLocation(v)
+		res = append(res, TestScoreComment{
+			Path:             v.Path,
+			Summary:          v.Summary,
+			Description:      v.Description,
+			DocumentationURL: v.DocumentationURL,
+			Location: CommentLocation{
+				File: location.Name,
+				Line: location.Line,
+				Path: v.Path,
+			},
+		})
+	}
+	return res
+}
+
+func convertCheck(v ks.Check) Check {
+	return Check{
+		Name:       v.Name,
+		ID:         v.ID,
+		TargetType: v.TargetType,
+		Comment:    v.Comment,
+		Optional:   v.Optional,
+	}
+}
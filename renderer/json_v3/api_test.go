@@ -0,0 +1,169 @@
+package json_v3
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getTestCard() *scorecard.Scorecard {
+	checks := []scorecard.TestScore{
+		{
+			Check: domain.Check{Name: "Test Warning", ID: "test-warning"},
+			Grade: scorecard.GradeWarning,
+			Comments: []scorecard.TestScoreComment{
+				{Path: "a", Summary: "summary", Description: "description", DocumentationURL: "https://kube-score.com/"},
+			},
+		},
+		{
+			Check: domain.Check{Name: "Test Critical", ID: "test-critical"},
+			Grade: scorecard.GradeCritical,
+		},
+		{
+			Check:    domain.Check{Name: "Test Ignored", ID: "test-ignored"},
+			Grade:    scorecard.GradeCritical,
+			Skipped:  true,
+			Ignored:  true,
+			Comments: []scorecard.TestScoreComment{{Summary: "Skipped because test-ignored is ignored"}},
+		},
+		{
+			Check: domain.Check{Name: "Test OK", ID: "test-ok", Optional: true},
+			Grade: scorecard.GradeAllOK,
+		},
+	}
+
+	return &scorecard.Scorecard{
+		"b": &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: "foo", Namespace: "foofoo"},
+			FileLocation: domain.FileLocation{Name: "foo.yaml", Line: 3},
+			QOSClass:     "Burstable",
+			Checks:       checks,
+		},
+		"a": &scorecard.ScoredObject{
+			TypeMeta:   v1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			ObjectMeta: v1.ObjectMeta{Name: "bar"},
+			Checks:     checks[3:],
+		},
+	}
+}
+
+func testOptions() Options {
+	return Options{
+		ToolVersion: "v1.2.3",
+		Config: &config.RunConfiguration{
+			KubernetesVersion:         config.Semver{Major: 1, Minor: 29},
+			EnabledOptionalTests:      map[string]struct{}{"test-ok": {}, "another": {}},
+			UseIgnoreChecksAnnotation: true,
+		},
+		IgnoredTests: map[string]struct{}{"ignored": {}},
+	}
+}
+
+func output(t *testing.T, card *scorecard.Scorecard, opts Options) []byte {
+	all, err := io.ReadAll(Output(card, opts))
+	assert.Nil(t, err)
+	return all
+}
+
+func validate(t *testing.T, data []byte) {
+	schema, err := jsonschema.CompileString("schema.json", string(Schema))
+	assert.Nil(t, err)
+
+	var v interface{}
+	assert.Nil(t, json.Unmarshal(data, &v))
+	assert.Nil(t, schema.Validate(v))
+}
+
+func TestOutput(t *testing.T) {
+	t.Parallel()
+	all := output(t, getTestCard(), testOptions())
+	validate(t, all)
+
+	var report Report
+	assert.Nil(t, json.Unmarshal(all, &report))
+
+	assert.Equal(t, "v3", report.Version)
+	assert.Equal(t, Tool{Name: "kube-score", Version: "v1.2.3"}, report.Tool)
+	assert.Equal(t, "v1.29", report.KubernetesVersion)
+	assert.Equal(t, Config{
+		EnabledOptionalTests:      []string{"another", "test-ok"},
+		IgnoredTests:              []string{"ignored"},
+		UseIgnoreChecksAnnotation: true,
+	}, report.Config)
+	assert.Equal(t, Summary{
+		Score:   61,
+		Objects: GradeCount{Critical: 1, OK: 1},
+		Checks:  GradeCount{Critical: 1, Warning: 1, OK: 2, Skipped: 1},
+		Namespaces: map[string]ScoreSummary{
+			"":       {Score: 100, GradeCount: GradeCount{OK: 1}},
+			"foofoo": {Score: 48, GradeCount: GradeCount{Critical: 1, Warning: 1, OK: 1, Skipped: 1}},
+		},
+		Kinds: map[string]ScoreSummary{
+			"Deployment": {Score: 48, GradeCount: GradeCount{Critical: 1, Warning: 1, OK: 1, Skipped: 1}},
+			"Service":    {Score: 100, GradeCount: GradeCount{OK: 1}},
+		},
+	}, report.Summary)
+
+	// Objects are sorted by name, and checks by ID
+	assert.Len(t, report.Objects, 2)
+	assert.Equal(t, "a", report.Objects[0].ObjectName)
+	assert.Equal(t, "ok", report.Objects[0].Grade)
+	assert.Equal(t, 100, report.Objects[0].Score)
+	obj := report.Objects[1]
+	assert.Equal(t, "critical", obj.Grade)
+	assert.Equal(t, 48, obj.Score)
+	var ids []string
+	for _, c := range obj.Checks {
+		ids = append(ids, c.Check.ID)
+	}
+	assert.Equal(t, []string{"test-critical", "test-ignored", "test-ok", "test-warning"}, ids)
+
+	assert.Equal(t, "skipped", obj.Checks[1].Result)
+	assert.True(t, obj.Checks[1].Ignored)
+	assert.Equal(t, []TestScoreComment{{
+		Path:             "a",
+		Summary:          "summary",
+		Description:      "description",
+		DocumentationURL: "https://kube-score.com/",
+		Location:         CommentLocation{File: "foo.yaml", Line: 3, Path: "a"},
+	}}, obj.Checks[3].Comments)
+}
+
+type testLines map[string]int
+
+func (l testLines) Line(path string) (int, bool) {
+	line, ok := l[path]
+	return line, ok
+}
+
+func TestOutputCommentLocation(t *testing.T) {
+	t.Parallel()
+	card := getTestCard()
+	(*card)["b"].FileLocation.Lines = testLines{"a": 12}
+
+	var report Report
+	assert.Nil(t, json.Unmarshal(output(t, card, testOptions()), &report))
+	assert.Equal(t, CommentLocation{File: "foo.yaml", Line: 12, Path: "a"}, report.Objects[1].Checks[3].Comments[0].Location)
+}
+
+func TestOutputIsStable(t *testing.T) {
+	t.Parallel()
+	first := output(t, getTestCard(), testOptions())
+	for i := 0; i < 10; i++ {
+		assert.True(t, bytes.Equal(first, output(t, getTestCard(), testOptions())))
+	}
+}
+
+func TestOutputEmpty(t *testing.T) {
+	t.Parallel()
+	validate(t, output(t, &scorecard.Scorecard{}, Options{}))
+}
@@ -0,0 +1,142 @@
+package json_v3
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"io"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// DiffSchema is the JSON Schema of the diff output, it refers to the definitions in Schema
+//
+//go:embed diff_schema.json
+var DiffSchema []byte
+
+type DiffReport struct {
+	Version string       `json:"version"`
+	Tool    Tool         `json:"tool"`
+	Summary DiffSummary  `json:"summary"`
+	Objects []ObjectDiff `json:"objects"`
+}
+
+type DiffSummary struct {
+	OldScore   int `json:"old_score"`
+	NewScore   int `json:"new_score"`
+	Introduced int `json:"introduced"`
+	Resolved   int `json:"resolved"`
+	Unchanged  int `json:"unchanged"`
+}
+
+type ObjectDiff struct {
+	ObjectName string   `json:"object_name"`
+	Kind       string   `json:"kind"`
+	APIVersion string   `json:"api_version"`
+	Name       string   `json:"name"`
+	Namespace  string   `json:"namespace"`
+	Location   Location `json:"location"`
+
+	// Status is "added", "removed", "changed" or "unchanged"
+	Status string `json:"status"`
+
+	// OldScore is nil if the object was added, and NewScore is nil if the object was removed
+	OldScore *int `json:"old_score"`
+	NewScore *int `json:"new_score"`
+
+	Introduced []Finding `json:"introduced"`
+	Resolved   []Finding `json:"resolved"`
+	Unchanged  []Finding `json:"unchanged"`
+}
+
+type Finding struct {
+	Fingerprint string `json:"fingerprint"`
+	Check       Check  `json:"check"`
+	Grade       int    `json:"grade"`
+	Result      string `json:"result"`
+
+	// Comment is nil for checks without comments
+	Comment *TestScoreComment `json:"comment"`
+}
+
+// Diff renders the findings that were introduced, resolved and unchanged in each object. Objects are sorted by
+// object_name.
+func Diff(diff scorecard.ScorecardDiff, opts Options) io.Reader {
+	report := DiffReport{
+		Version: formatVersion,
+		Tool: Tool{
+			Name:    "kube-score",
+			Version: opts.ToolVersion,
+		},
+		Summary: DiffSummary{
+			OldScore:   diff.Old.Score,
+			NewScore:   diff.New.Score,
+			Introduced: diff.Introduced,
+			Resolved:   diff.Resolved,
+			Unchanged:  diff.Unchanged,
+		},
+		Objects: make([]ObjectDiff, 0, len(diff.Objects)),
+	}
+
+	for _, d := range diff.Objects {
+		o := d.Object()
+
+		obj := ObjectDiff{
+			ObjectName: d.Key,
+			Kind:       o.TypeMeta.Kind,
+			APIVersion: o.TypeMeta.APIVersion,
+			Name:       o.ObjectMeta.Name,
+			Namespace:  o.ObjectMeta.Namespace,
+			Location:   Location{File: o.FileLocation.Name, Line: o.FileLocation.Line},
+			Introduced: convertFindings(d.Introduced, d.New),
+			Resolved:   convertFindings(d.Resolved, d.Old),
+			Unchanged:  convertFindings(d.Unchanged, d.New),
+		}
+
+		switch {
+		case d.Old == nil:
+			obj.Status = "added"
+		case d.New == nil:
+			obj.Status = "removed"
+		case d.Changed():
+			obj.Status = "changed"
+		default:
+			obj.Status = "unchanged"
+		}
+
+		if d.Old != nil {
+			score := d.Old.Summary().Score
+			obj.OldScore = &score
+		}
+		if d.New != nil {
+			score := d.New.Summary().Score
+			obj.NewScore = &score
+		}
+
+		report.Objects = append(report.Objects, obj)
+	}
+
+	j, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	return bytes.NewBuffer(j)
+}
+
+// convertFindings converts the findings of an object, the comments have the location of their path in the object
+func convertFindings(in []scorecard.Finding, o *scorecard.ScoredObject) []Finding {
+	res := make([]Finding, 0, len(in))
+	for _, v := range in {
+		f := Finding{
+			Fingerprint: v.Fingerprint,
+			Check:       convertCheck(v.Check),
+			Grade:       int(v.Grade),
+			Result:      result(scorecard.TestScore{Grade: v.Grade}),
+		}
+		if v.Comment != (scorecard.TestScoreComment{}) {
+			f.Comment = &convertComments(o, []scorecard.TestScoreComment{v.Comment})[0]
+		}
+		res = append(res, f)
+	}
+	return res
+}
@@ -0,0 +1,100 @@
+package json_v3
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getTestDiff() scorecard.ScorecardDiff {
+	object := func(name string, checks ...scorecard.TestScore) *scorecard.ScoredObject {
+		return &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: name, Namespace: "foofoo"},
+			FileLocation: domain.FileLocation{Name: name + ".yaml", Line: 2},
+			Checks:       checks,
+		}
+	}
+	check := func(id string, grade scorecard.Grade, comments ...scorecard.TestScoreComment) scorecard.TestScore {
+		return scorecard.TestScore{Check: domain.Check{ID: id, Name: id}, Grade: grade, Comments: comments}
+	}
+
+	old := scorecard.Scorecard{
+		"a": object("a", check("test-warning", scorecard.GradeWarning)),
+		"b": object("b", check("test-warning", scorecard.GradeWarning)),
+	}
+	new := scorecard.Scorecard{
+		"a": object("a", check("test-warning", scorecard.GradeAllOK)),
+		"b": object("b", check("test-warning", scorecard.GradeWarning)),
+		"c": object("c", check("test-critical", scorecard.GradeCritical, scorecard.TestScoreComment{Path: "x", Summary: "summary"})),
+	}
+
+	return scorecard.Diff(old, new)
+}
+
+func validateDiff(t *testing.T, data []byte) {
+	compiler := jsonschema.NewCompiler()
+	assert.Nil(t, compiler.AddResource("https://github.com/zegl/kube-score/blob/master/renderer/json_v3/schema.json", bytes.NewReader(Schema)))
+	assert.Nil(t, compiler.AddResource("https://github.com/zegl/kube-score/blob/master/renderer/json_v3/diff_schema.json", bytes.NewReader(DiffSchema)))
+	schema, err := compiler.Compile("https://github.com/zegl/kube-score/blob/master/renderer/json_v3/diff_schema.json")
+	assert.Nil(t, err)
+
+	var v interface{}
+	assert.Nil(t, json.Unmarshal(data, &v))
+	assert.Nil(t, schema.Validate(v))
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	all, err := io.ReadAll(Diff(getTestDiff(), Options{ToolVersion: "v1.2.3"}))
+	assert.Nil(t, err)
+	validateDiff(t, all)
+
+	var report DiffReport
+	assert.Nil(t, json.Unmarshal(all, &report))
+
+	assert.Equal(t, "v3", report.Version)
+	assert.Equal(t, Tool{Name: "kube-score", Version: "v1.2.3"}, report.Tool)
+	assert.Equal(t, DiffSummary{OldScore: 44, NewScore: 48, Introduced: 1, Resolved: 1, Unchanged: 1}, report.Summary)
+
+	assert.Len(t, report.Objects, 3)
+	var statuses []string
+	for _, o := range report.Objects {
+		statuses = append(statuses, o.ObjectName+"="+o.Status)
+	}
+	assert.Equal(t, []string{"a=changed", "b=unchanged", "c=added"}, statuses)
+
+	a := report.Objects[0]
+	assert.Equal(t, 44, *a.OldScore)
+	assert.Equal(t, 100, *a.NewScore)
+	assert.Empty(t, a.Introduced)
+	assert.Len(t, a.Resolved, 1)
+	assert.Equal(t, "warning", a.Resolved[0].Result)
+	assert.Nil(t, a.Resolved[0].Comment)
+
+	c := report.Objects[2]
+	assert.Nil(t, c.OldScore)
+	assert.Equal(t, 0, *c.NewScore)
+	assert.Len(t, c.Introduced, 1)
+	assert.Equal(t, "critical", c.Introduced[0].Result)
+	assert.NotEmpty(t, c.Introduced[0].Fingerprint)
+	assert.Equal(t, &TestScoreComment{
+		Path:     "x",
+		Summary:  "summary",
+		Location: CommentLocation{File: "c.yaml", Line: 2, Path: "x"},
+	}, c.Introduced[0].Comment)
+}
+
+func TestDiffEmpty(t *testing.T) {
+	t.Parallel()
+	all, err := io.ReadAll(Diff(scorecard.Diff(scorecard.New(), scorecard.New()), Options{}))
+	assert.Nil(t, err)
+	validateDiff(t, all)
+}
@@ -3,6 +3,8 @@ package junit
 import (
 	"bytes"
 	"io"
+	"sort"
+	"strconv"
 
 	"github.com/jstemmer/go-junit-report/v2/junit"
 	"github.com/zegl/kube-score/scorecard"
@@ -14,10 +16,22 @@ func JUnit(scoreCard *scorecard.Scorecard) io.Reader {
 		Name: "kube-score",
 	}
 
-	for _, scoredObject := range *scoreCard {
+	// Add the objects sorted by scorecard key
+	var keys []string
+	for k := range *scoreCard {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		scoredObject := (*scoreCard)[key]
 		testsuite := junit.Testsuite{
 			Name: scoredObject.HumanFriendlyRef(),
 		}
+		if scoredObject.QOSClass != "" {
+			testsuite.AddProperty("qos-class", string(scoredObject.QOSClass))
+		}
+		testsuite.AddProperty("score", strconv.Itoa(scoredObject.Summary().Score))
 
 		for _, testScore := range scoredObject.Checks {
 			if len(testScore.Comments) == 0 {
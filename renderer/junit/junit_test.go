@@ -98,6 +98,9 @@ func TestJUnitOutput(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, `<testsuites name="kube-score" tests="10" failures="4" skipped="4">
 	<testsuite name="foo/foofoo v1/Testing" tests="5" failures="2" errors="0" id="0" skipped="2" time="">
+		<properties>
+			<property name="score" value="72"></property>
+		</properties>
 		<testcase name="test-warning-two-comments" classname="foo/foofoo v1/Testing">
 			<failure message="(a) summary"></failure>
 		</testcase>
@@ -113,6 +116,9 @@ func TestJUnitOutput(t *testing.T) {
 		</testcase>
 	</testsuite>
 	<testsuite name="bar-no-namespace v1/Testing" tests="5" failures="2" errors="0" id="0" skipped="2" time="">
+		<properties>
+			<property name="score" value="72"></property>
+		</properties>
 		<testcase name="test-warning-two-comments" classname="bar-no-namespace v1/Testing">
 			<failure message="(a) summary"></failure>
 		</testcase>
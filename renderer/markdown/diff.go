@@ -0,0 +1,92 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func writeFindings(w io.Writer, title string, findings []scorecard.Finding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "**%s**\n\n", title)
+	for _, f := range findings {
+		_, _ = fmt.Fprintf(w, "- **[%s] %s**\n", f.Grade.String(), escape(f.Check.Name))
+		if f.Comment.Summary != "" {
+			writeComment(w, f.Comment)
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+func writeObjectDiff(w io.Writer, d scorecard.ObjectDiff, opts Options) {
+	// Objects with new findings are expanded by default
+	open := ""
+	if len(d.Introduced) > 0 {
+		open = " open"
+	}
+
+	var score string
+	switch {
+	case d.Old == nil:
+		score = fmt.Sprintf("added, score %d", d.New.Summary().Score)
+	case d.New == nil:
+		score = "removed"
+	default:
+		score = fmt.Sprintf("score %d → %d", d.Old.Summary().Score, d.New.Summary().Score)
+	}
+
+	_, _ = fmt.Fprintf(w, "<details%s>\n<summary>%s: %d introduced, %d resolved (%s)</summary>\n\n",
+		open, html.EscapeString(objectTitle(d.Object())), len(d.Introduced), len(d.Resolved), score)
+
+	if link := fileLink(d.Object(), opts.LinkBaseURL); link != "" {
+		_, _ = fmt.Fprintf(w, "File: %s\n\n", link)
+	}
+
+	writeFindings(w, "Introduced", d.Introduced)
+	writeFindings(w, "Resolved", d.Resolved)
+	if opts.Verbose >= 1 {
+		writeFindings(w, "Unchanged", d.Unchanged)
+	}
+
+	_, _ = fmt.Fprint(w, "</details>\n\n")
+}
+
+// Diff renders the findings that were introduced and resolved in each object as a Markdown document, that can be used
+// in pull request comments. Objects without changes, and the unchanged findings, are included if opts.Verbose is at
+// least 1.
+func Diff(diff scorecard.ScorecardDiff, opts Options) io.Reader {
+	var objects []scorecard.ObjectDiff
+	for _, d := range diff.Objects {
+		if d.Changed() || opts.Verbose >= 1 {
+			objects = append(objects, d)
+		}
+	}
+
+	// Show the objects with new findings first, so that they are kept if the output is truncated
+	sort.SliceStable(objects, func(i, j int) bool {
+		return len(objects[i].Introduced) > 0 && len(objects[j].Introduced) == 0
+	})
+
+	w := bytes.NewBufferString("")
+	_, _ = fmt.Fprint(w, "## kube-score diff\n\n")
+	_, _ = fmt.Fprintf(w, "**Score: %d/100 → %d/100** (%d introduced, %d resolved and %d unchanged findings)\n\n",
+		diff.Old.Score, diff.New.Score, diff.Introduced, diff.Resolved, diff.Unchanged)
+
+	sections := make([]*bytes.Buffer, len(objects))
+	total := w.Len()
+	for i, d := range objects {
+		sections[i] = bytes.NewBufferString("")
+		writeObjectDiff(sections[i], d, opts)
+		total += sections[i].Len()
+	}
+
+	writeSections(w, sections, total, opts.MaxLength)
+	return w
+}
@@ -0,0 +1,79 @@
+package markdown
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getTestDiff() scorecard.ScorecardDiff {
+	object := func(name string, checks ...scorecard.TestScore) *scorecard.ScoredObject {
+		return &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: name, Namespace: "foofoo"},
+			FileLocation: domain.FileLocation{Name: name + ".yaml", Line: 1},
+			Checks:       checks,
+		}
+	}
+	check := func(id string, grade scorecard.Grade, comments ...scorecard.TestScoreComment) scorecard.TestScore {
+		return scorecard.TestScore{Check: domain.Check{ID: id, Name: id}, Grade: grade, Comments: comments}
+	}
+
+	old := scorecard.Scorecard{
+		"a": object("a", check("test-warning", scorecard.GradeWarning)),
+		"b": object("b", check("test-warning", scorecard.GradeWarning)),
+	}
+	new := scorecard.Scorecard{
+		"a": object("a", check("test-warning", scorecard.GradeAllOK)),
+		"b": object("b", check("test-warning", scorecard.GradeWarning)),
+		"c": object("c", check("test-critical", scorecard.GradeCritical, scorecard.TestScoreComment{Path: "x", Summary: "summary"})),
+	}
+
+	return scorecard.Diff(old, new)
+}
+
+func renderDiff(t *testing.T, opts Options) string {
+	all, err := io.ReadAll(Diff(getTestDiff(), opts))
+	assert.Nil(t, err)
+	return string(all)
+}
+
+func TestMarkdownDiff(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "## kube-score diff\n\n"+
+		"**Score: 44/100 → 48/100** (1 introduced, 1 resolved and 1 unchanged findings)\n\n"+
+		"<details open>\n<summary>apps/v1/Deployment c in foofoo: 1 introduced, 0 resolved (added, score 0)</summary>\n\n"+
+		"File: [c.yaml:1](https://example.com/c.yaml#L1)\n\n"+
+		"**Introduced**\n\n"+
+		"- **[CRITICAL] test-critical**\n"+
+		"  - `x`: summary\n\n"+
+		"</details>\n\n"+
+		"<details>\n<summary>apps/v1/Deployment a in foofoo: 0 introduced, 1 resolved (score 44 → 100)</summary>\n\n"+
+		"File: [a.yaml:1](https://example.com/a.yaml#L1)\n\n"+
+		"**Resolved**\n\n"+
+		"- **[WARNING] test-warning**\n\n"+
+		"</details>\n\n", renderDiff(t, Options{LinkBaseURL: "https://example.com/"}))
+}
+
+func TestMarkdownDiffVerbose(t *testing.T) {
+	t.Parallel()
+	out := renderDiff(t, Options{Verbose: 1})
+	assert.Contains(t, out, "<summary>apps/v1/Deployment b in foofoo: 0 introduced, 0 resolved (score 44 → 44)</summary>\n\n"+
+		"File: `b.yaml:1`\n\n"+
+		"**Unchanged**\n\n"+
+		"- **[WARNING] test-warning**\n\n")
+}
+
+func TestMarkdownDiffTruncated(t *testing.T) {
+	t.Parallel()
+	out := renderDiff(t, Options{MaxLength: 400})
+	assert.LessOrEqual(t, len(out), 400)
+	assert.Contains(t, out, "apps/v1/Deployment c in foofoo")
+	assert.NotContains(t, out, "apps/v1/Deployment a in foofoo")
+	assert.True(t, strings.HasSuffix(out, "_1 more objects are not shown, the report has been truncated to 400 characters._\n"))
+}
@@ -0,0 +1,231 @@
+// Package markdown is currently considered to be in alpha status, and is not covered
+// by the API stability guarantees
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/zegl/kube-score/renderer/internal"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// Options configures the Markdown output
+type Options struct {
+	// Verbose has the same meaning as the --verbose flag of the human output. OK checks are included if it is at least
+	// 1, and skipped checks if it is at least 2.
+	Verbose int
+
+	// LinkBaseURL is prepended to the file names to create links to the files, for example
+	// "https://github.com/zegl/kube-score/blob/master/". File names are not linked if it's empty.
+	LinkBaseURL string
+
+	// MaxLength is the highest number of bytes in the output, not limited if zero. Objects that don't fit are left out
+	// of the output, and a note about the truncation is added at the end. GitHub limits comments to 65536 characters.
+	MaxLength int
+}
+
+// The grade of an object is the lowest grade of all checks that are not skipped
+func objectGrade(o *scorecard.ScoredObject) scorecard.Grade {
+	grade := scorecard.GradeAllOK
+	for _, c := range o.Checks {
+		if !c.Skipped && c.Grade < grade {
+			grade = c.Grade
+		}
+	}
+	return grade
+}
+
+func gradeName(g scorecard.Grade) string {
+	switch {
+	case g <= scorecard.GradeCritical:
+		return "Critical"
+	case g <= scorecard.GradeWarning:
+		return "Warning"
+	default:
+		return "OK"
+	}
+}
+
+// escape escapes text so that it is shown as-is, both in Markdown and inside of HTML tags
+func escape(s string) string {
+	s = html.EscapeString(s)
+	replacer := strings.NewReplacer("|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+	return replacer.Replace(s)
+}
+
+func objectTitle(o *scorecard.ScoredObject) string {
+	title := fmt.Sprintf("%s/%s %s", o.TypeMeta.APIVersion, o.TypeMeta.Kind, o.ObjectMeta.Name)
+	if o.ObjectMeta.Namespace != "" {
+		title += " in " + o.ObjectMeta.Namespace
+	}
+	return title
+}
+
+func fileLink(o *scorecard.ScoredObject, linkBaseURL string) string {
+	if o.FileLocation.Name == "" {
+		return ""
+	}
+	location := o.FileLocation.Name
+	if o.FileLocation.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, o.FileLocation.Line)
+	}
+	if linkBaseURL == "" {
+		return "`" + location + "`"
+	}
+	url := linkBaseURL + strings.TrimPrefix(strings.TrimPrefix(o.FileLocation.Name, "./"), "/")
+	if o.FileLocation.Line > 0 {
+		url += fmt.Sprintf("#L%d", o.FileLocation.Line)
+	}
+	return fmt.Sprintf("[%s](%s)", escape(location), url)
+}
+
+func writeCheck(w io.Writer, card scorecard.TestScore) {
+	if card.Skipped {
+		_, _ = fmt.Fprintf(w, "- **[SKIPPED] %s**\n", escape(card.Check.Name))
+	} else {
+		_, _ = fmt.Fprintf(w, "- **[%s] %s**\n", card.Grade.String(), escape(card.Check.Name))
+	}
+
+	for _, comment := range card.Comments {
+		writeComment(w, comment)
+	}
+}
+
+func writeComment(w io.Writer, comment scorecard.TestScoreComment) {
+	line := escape(comment.Summary)
+	if comment.Path != "" {
+		line = "`" + strings.ReplaceAll(comment.Path, "`", "'") + "`: " + line
+	}
+	_, _ = fmt.Fprintf(w, "  - %s", line)
+	if comment.Description != "" {
+		_, _ = fmt.Fprintf(w, "<br>\n    %s", escape(comment.Description))
+	}
+	if comment.DocumentationURL != "" {
+		_, _ = fmt.Fprintf(w, " [More information](%s)", comment.DocumentationURL)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+func writeObject(w io.Writer, o *scorecard.ScoredObject, opts Options) {
+	grade := objectGrade(o)
+
+	// Objects with problems are expanded by default
+	open := ""
+	if grade <= scorecard.GradeWarning {
+		open = " open"
+	}
+
+	_, _ = fmt.Fprintf(w, "<details%s>\n<summary><b>%s</b> %s (score %d)</summary>\n\n", open, strings.ToUpper(gradeName(grade)), html.EscapeString(objectTitle(o)), o.Summary().Score)
+
+	if link := fileLink(o, opts.LinkBaseURL); link != "" {
+		_, _ = fmt.Fprintf(w, "File: %s\n\n", link)
+	}
+
+	for _, card := range o.Checks {
+		switch {
+		case card.Skipped && opts.Verbose < 2:
+			continue
+		case !card.Skipped && card.Grade >= scorecard.GradeAllOK && opts.Verbose < 1:
+			continue
+		}
+		writeCheck(w, card)
+	}
+
+	_, _ = fmt.Fprint(w, "\n</details>\n\n")
+}
+
+// Markdown renders the scorecard as a Markdown document, that can be used in pull request comments
+func Markdown(scoreCard *scorecard.Scorecard, opts Options) io.Reader {
+	var keys []string
+	for k := range *scoreCard {
+		keys = append(keys, k)
+	}
+
+	// Show the objects with the most severe problems first, so that they are kept if the output is truncated
+	sort.Slice(keys, func(i, j int) bool {
+		gi, gj := objectGrade((*scoreCard)[keys[i]]), objectGrade((*scoreCard)[keys[j]])
+		if gi != gj {
+			return gi < gj
+		}
+		return keys[i] < keys[j]
+	})
+
+	counts := make(map[string]int)
+	for _, key := range keys {
+		counts[gradeName(objectGrade((*scoreCard)[key]))]++
+	}
+
+	summary := scoreCard.Summary()
+
+	w := bytes.NewBufferString("")
+	_, _ = fmt.Fprint(w, "## kube-score\n\n")
+	_, _ = fmt.Fprintf(w, "**Score: %d/100** (%d critical, %d warning, %d ok and %d skipped checks)\n\n",
+		summary.Overall.Score, summary.Overall.Critical, summary.Overall.Warning, summary.Overall.OK, summary.Overall.Skipped)
+	_, _ = fmt.Fprint(w, "| Grade | Objects |\n| --- | ---: |\n")
+	for _, name := range []string{"Critical", "Warning", "OK"} {
+		_, _ = fmt.Fprintf(w, "| %s | %d |\n", name, counts[name])
+	}
+	_, _ = fmt.Fprintln(w)
+	writeScoreTable(w, summary)
+
+	sections := make([]*bytes.Buffer, len(keys))
+	total := w.Len()
+	for i, key := range keys {
+		sections[i] = bytes.NewBufferString("")
+		writeObject(sections[i], (*scoreCard)[key], opts)
+		total += sections[i].Len()
+	}
+
+	writeSections(w, sections, total, opts.MaxLength)
+	return w
+}
+
+// writeSections writes the sections of all objects, or as many as fit in maxLength followed by a truncation note
+func writeSections(w *bytes.Buffer, sections []*bytes.Buffer, total, maxLength int) {
+	for i, section := range sections {
+		// Keep room for the truncation note, if the output doesn't fit
+		if maxLength > 0 && total > maxLength {
+			note := truncationNote(len(sections)-i-1, maxLength)
+			if w.Len()+section.Len()+len(note) > maxLength {
+				_, _ = fmt.Fprint(w, truncationNote(len(sections)-i, maxLength))
+				break
+			}
+		}
+		_, _ = io.Copy(w, section)
+	}
+}
+
+// writeScoreTable writes the score of each namespace and kind
+func writeScoreTable(w io.Writer, summary scorecard.ScorecardSummary) {
+	if len(summary.Namespaces) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprint(w, "<details>\n<summary>Score by namespace and kind</summary>\n\n")
+	_, _ = fmt.Fprint(w, "| | Score | Critical | Warning | OK | Skipped |\n| --- | ---: | ---: | ---: | ---: | ---: |\n")
+
+	row := func(name string, s scorecard.Summary) {
+		_, _ = fmt.Fprintf(w, "| %s | %d | %d | %d | %d | %d |\n", name, s.Score, s.Critical, s.Warning, s.OK, s.Skipped)
+	}
+	for _, ns := range internal.SortedKeys(summary.Namespaces) {
+		name := "Namespace " + escape(ns)
+		if ns == "" {
+			name = "No namespace"
+		}
+		row(name, summary.Namespaces[ns])
+	}
+	for _, kind := range internal.SortedKeys(summary.Kinds) {
+		row("Kind "+escape(kind), summary.Kinds[kind])
+	}
+
+	_, _ = fmt.Fprint(w, "\n</details>\n\n")
+}
+
+func truncationNote(omitted, maxLength int) string {
+	return fmt.Sprintf("_%d more objects are not shown, the report has been truncated to %d characters._\n", omitted, maxLength)
+}
@@ -0,0 +1,140 @@
+package markdown
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getTestCard() *scorecard.Scorecard {
+	checks := []scorecard.TestScore{
+		{
+			Check: domain.Check{
+				Name: "test-warning-two-comments",
+			},
+			Grade: scorecard.GradeWarning,
+			Comments: []scorecard.TestScoreComment{
+				{
+					Path:             "a",
+					Summary:          "summary",
+					Description:      "description <b>|",
+					DocumentationURL: "https://kube-score.com/",
+				},
+				{
+					// No path
+					Summary: "summary",
+				},
+			},
+		},
+		{
+			Check: domain.Check{
+				Name: "test-ok-comment",
+			},
+			Grade: scorecard.GradeAllOK,
+			Comments: []scorecard.TestScoreComment{
+				{
+					Path:    "a",
+					Summary: "ok summary",
+				},
+			},
+		},
+		{
+			Check: domain.Check{
+				Name: "test-skipped-comment",
+			},
+			Skipped: true,
+			Comments: []scorecard.TestScoreComment{
+				{
+					Summary: "skipped sum",
+				},
+			},
+		},
+	}
+
+	return &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta: v1.TypeMeta{
+				Kind:       "Testing",
+				APIVersion: "v1",
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "foofoo",
+			},
+			FileLocation: domain.FileLocation{Name: "./deploy/foo.yaml", Line: 12},
+			Checks:       checks,
+		},
+
+		// No namespace, and no problems
+		"b": &scorecard.ScoredObject{
+			TypeMeta: v1.TypeMeta{
+				Kind:       "Testing",
+				APIVersion: "v1",
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name: "bar-no-namespace",
+			},
+			Checks: checks[1:],
+		},
+	}
+}
+
+func render(t *testing.T, opts Options) string {
+	all, err := io.ReadAll(Markdown(getTestCard(), opts))
+	assert.Nil(t, err)
+	return string(all)
+}
+
+func TestMarkdownOutput(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "## kube-score\n\n"+
+		"**Score: 81/100** (0 critical, 1 warning, 2 ok and 2 skipped checks)\n\n"+
+		"| Grade | Objects |\n| --- | ---: |\n| Critical | 0 |\n| Warning | 1 |\n| OK | 1 |\n\n"+
+		"<details>\n<summary>Score by namespace and kind</summary>\n\n"+
+		"| | Score | Critical | Warning | OK | Skipped |\n| --- | ---: | ---: | ---: | ---: | ---: |\n"+
+		"| No namespace | 100 | 0 | 0 | 1 | 1 |\n"+
+		"| Namespace foofoo | 72 | 0 | 1 | 1 | 1 |\n"+
+		"| Kind Testing | 81 | 0 | 1 | 2 | 2 |\n"+
+		"\n</details>\n\n"+
+		"<details open>\n<summary><b>WARNING</b> v1/Testing foo in foofoo (score 72)</summary>\n\n"+
+		"File: [./deploy/foo.yaml:12](https://example.com/blob/main/deploy/foo.yaml#L12)\n\n"+
+		"- **[WARNING] test-warning-two-comments**\n"+
+		"  - `a`: summary<br>\n    description &lt;b&gt;\\| [More information](https://kube-score.com/)\n"+
+		"  - summary\n"+
+		"\n</details>\n\n"+
+		"<details>\n<summary><b>OK</b> v1/Testing bar-no-namespace (score 100)</summary>\n\n"+
+		"\n</details>\n\n",
+		render(t, Options{LinkBaseURL: "https://example.com/blob/main/"}))
+}
+
+func TestMarkdownOutputVerbose(t *testing.T) {
+	t.Parallel()
+	out := render(t, Options{Verbose: 2})
+	assert.Contains(t, out, "File: `./deploy/foo.yaml:12`\n")
+	assert.Contains(t, out, "- **[OK] test-ok-comment**\n  - `a`: ok summary\n")
+	assert.Contains(t, out, "- **[SKIPPED] test-skipped-comment**\n  - skipped sum\n")
+}
+
+func TestMarkdownOutputTruncated(t *testing.T) {
+	t.Parallel()
+	full := render(t, Options{Verbose: 2})
+	maxLength := strings.Index(full, "<details>\n<summary><b>OK") + 100
+	out := render(t, Options{Verbose: 2, MaxLength: maxLength})
+	assert.LessOrEqual(t, len(out), maxLength)
+	assert.Contains(t, out, "v1/Testing foo in foofoo")
+	assert.NotContains(t, out, "bar-no-namespace")
+	assert.True(t, strings.HasSuffix(out, "_1 more objects are not shown, the report has been truncated to "+strconv.Itoa(maxLength)+" characters._\n"))
+
+	// Nothing but the summary fits
+	out = render(t, Options{Verbose: 2, MaxLength: 200})
+	assert.True(t, strings.HasSuffix(out, "_2 more objects are not shown, the report has been truncated to 200 characters._\n"))
+
+	// Everything fits
+	assert.Equal(t, full, render(t, Options{Verbose: 2, MaxLength: len(full)}))
+}
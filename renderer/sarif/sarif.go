@@ -4,82 +4,202 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
 
 	"github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/sarif"
 	"github.com/zegl/kube-score/scorecard"
 )
 
+// srcRoot is the base of relative file URIs, GitHub code scanning resolves it to the root of the repository
+const srcRoot = "%SRCROOT%"
+
+const checksDocumentationURL = "https://github.com/zegl/kube-score/blob/master/README_CHECKS.md"
+
+const fingerprintKey = "kubeScoreFinding/v1"
+
+func level(grade scorecard.Grade) string {
+	if grade <= scorecard.GradeCritical {
+		return "error"
+	}
+	return "warning"
+}
+
+func securitySeverity(grade scorecard.Grade) string {
+	if grade <= scorecard.GradeCritical {
+		return "8.0"
+	}
+	return "5.0"
+}
+
+func issueSeverity(grade scorecard.Grade) string {
+	if grade <= scorecard.GradeCritical {
+		return "HIGH"
+	}
+	return "MEDIUM"
+}
+
+func artifactLocation(name string) sarif.ArtifactLocation {
+	if filepath.IsAbs(name) {
+		return sarif.ArtifactLocation{URI: (&url.URL{Scheme: "file", Path: filepath.ToSlash(name)}).String()}
+	}
+	return sarif.ArtifactLocation{
+		URI:       (&url.URL{Path: filepath.ToSlash(name)}).String(),
+		URIBaseID: srcRoot,
+	}
+}
+
 func Output(input *scorecard.Scorecard) io.Reader {
-	var results []sarif.Results
+	results := make([]sarif.Results, 0)
 	var rules []sarif.Rules
+	ruleIndex := make(map[string]int)
+	ruleGrade := make(map[string]scorecard.Grade)
 
-	addRule := func(check domain.Check) {
-		for _, r := range rules {
-			if r.ID == check.ID {
-				return
+	addRule := func(check domain.Check, grade scorecard.Grade, comments []scorecard.TestScoreComment) int {
+		i, ok := ruleIndex[check.ID]
+		if !ok {
+			description := check.Comment
+			if description == "" {
+				description = check.Name
 			}
+			i = len(rules)
+			ruleIndex[check.ID] = i
+			ruleGrade[check.ID] = grade
+			rules = append(rules, sarif.Rules{
+				ID:               check.ID,
+				Name:             check.Name,
+				ShortDescription: &sarif.Message{Text: description},
+				Help:             &sarif.Message{Text: description},
+				HelpURI:          checksDocumentationURL,
+				Properties: &sarif.RulesProperties{
+					Tags: []string{"kubernetes", check.TargetType},
+				},
+			})
 		}
 
-		rules = append(rules, sarif.Rules{
-			ID:   check.ID,
-			Name: check.Name,
-		})
+		// Use the most severe grade of all results as the default of the rule
+		if grade < ruleGrade[check.ID] {
+			ruleGrade[check.ID] = grade
+		}
+
+		// Link to the documentation of the check, if it has any
+		if rules[i].HelpURI == checksDocumentationURL {
+			for _, comment := range comments {
+				if comment.DocumentationURL != "" {
+					rules[i].HelpURI = comment.DocumentationURL
+					break
+				}
+			}
+		}
+		return i
 	}
 
-	for _, v := range *input {
+	var keys []string
+	for k := range *input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		v := (*input)[key]
+
+		location := sarif.Locations{
+			PhysicalLocation: sarif.PhysicalLocation{
+				ArtifactLocation: artifactLocation(v.FileLocation.Name),
+			},
+		}
+		if v.FileLocation.Line > 0 {
+			location.PhysicalLocation.Region = &sarif.Region{StartLine: v.FileLocation.Line}
+		}
+
 		for _, check := range v.Checks {
-			if check.Skipped {
+			// Checks that are ignored with annotations are reported as suppressed, other skipped checks are not reported
+			if check.Skipped && !check.Ignored {
 				continue
 			}
-
-			var level string
-			switch check.Grade {
-			case scorecard.GradeCritical:
-				level = "error"
-			case scorecard.GradeWarning:
-				level = "warning"
-			default:
+			if check.Grade > scorecard.GradeWarning {
 				continue
 			}
 
-			addRule(check.Check)
+			// Suppressed results keep the message and path of the finding, so that they have the same fingerprint
+			comments := check.Comments
+			if check.Ignored {
+				comments = check.IgnoredComments
+			}
+
+			index := addRule(check.Check, check.Grade, comments)
+
+			var suppressions []sarif.Suppression
+			if check.Ignored {
+				suppressions = []sarif.Suppression{{
+					Kind:          "inSource",
+					Justification: "Ignored with the kube-score/ignore annotation",
+				}}
+			}
+
+			if len(comments) == 0 {
+				comments = []scorecard.TestScoreComment{{Summary: check.Check.Name}}
+			}
+
+			fingerprints := v.CommentFingerprints(check.Check.ID, comments)
+			for i, comment := range comments {
+				text := comment.Summary
+				if comment.Path != "" {
+					text = "(" + comment.Path + ") " + text
+				}
+				if comment.Description != "" {
+					text += ": " + comment.Description
+				}
 
-			for _, comment := range check.Comments {
 				results = append(results, sarif.Results{
 					Message: sarif.Message{
-						Text: comment.Summary,
+						Text: v.HumanFriendlyRef() + ": " + text,
 					},
-					RuleID: check.Check.ID,
-					Level:  level,
+					RuleID:    check.Check.ID,
+					RuleIndex: index,
+					Level:     level(check.Grade),
 					Properties: sarif.ResultsProperties{
-						IssueConfidence: "HIGH",
-						IssueSeverity:   "HIGH",
+						IssueSeverity: issueSeverity(check.Grade),
 					},
-					Locations: []sarif.Locations{
-						{
-							PhysicalLocation: sarif.PhysicalLocation{
-								ArtifactLocation: sarif.ArtifactLocation{
-									URI: "file://" + v.FileLocation.Name,
-								},
-								ContextRegion: sarif.ContextRegion{
-									StartLine: v.FileLocation.Line,
-								},
-							},
-						},
+					Locations: []sarif.Locations{location},
+					PartialFingerprints: map[string]string{
+						fingerprintKey: fingerprints[i],
 					},
+					Suppressions: suppressions,
 				})
 			}
 		}
 	}
 
+	for i := range rules {
+		grade := ruleGrade[rules[i].ID]
+		rules[i].DefaultConfiguration = &sarif.DefaultConfiguration{Level: level(grade)}
+		rules[i].Properties.SecuritySeverity = securitySeverity(grade)
+	}
+
+	summary := input.Summary().Overall
+
 	run := sarif.Run{
+		Properties: sarif.Properties{
+			Score:    &summary.Score,
+			Critical: summary.Critical,
+			Warning:  summary.Warning,
+			OK:       summary.OK,
+			Skipped:  summary.Skipped,
+		},
 		Tool: sarif.Tool{
 			Driver: sarif.Driver{
 				Name:  "kube-score",
 				Rules: rules,
 			},
 		},
+		OriginalURIBaseIDs: map[string]sarif.OriginalURIBaseID{
+			srcRoot: {
+				Description: &sarif.Message{Text: "The directory that kube-score was run in"},
+			},
+		},
 		Conversion: sarif.Conversion{
 			Tool: sarif.Tool{
 				Driver: sarif.Driver{
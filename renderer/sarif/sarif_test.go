@@ -0,0 +1,152 @@
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/sarif"
+	"github.com/zegl/kube-score/scorecard"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getTestCard() *scorecard.Scorecard {
+	checks := []scorecard.TestScore{
+		{
+			Check: domain.Check{Name: "Test Critical", ID: "test-critical", TargetType: "Deployment", Comment: "Makes sure that things are not critical"},
+			Grade: scorecard.GradeCritical,
+			Comments: []scorecard.TestScoreComment{
+				{Path: "a", Summary: "summary", Description: "description", DocumentationURL: "https://kube-score.com/"},
+			},
+		},
+		{
+			Check:    domain.Check{Name: "Test Warning", ID: "test-warning", TargetType: "Deployment"},
+			Grade:    scorecard.GradeWarning,
+			Comments: []scorecard.TestScoreComment{{Summary: "warning"}},
+		},
+		{
+			Check:    domain.Check{Name: "Test OK", ID: "test-ok"},
+			Grade:    scorecard.GradeAllOK,
+			Comments: []scorecard.TestScoreComment{{Summary: "ok"}},
+		},
+		{
+			Check:    domain.Check{Name: "Test Ignored", ID: "test-ignored"},
+			Grade:    scorecard.GradeCritical,
+			Skipped:  true,
+			Ignored:  true,
+			Comments: []scorecard.TestScoreComment{{Summary: "Skipped because test-ignored is ignored"}},
+			IgnoredComments: []scorecard.TestScoreComment{
+				{Path: "b", Summary: "ignored summary"},
+			},
+		},
+		{
+			Check:    domain.Check{Name: "Test Skipped", ID: "test-skipped"},
+			Grade:    scorecard.GradeCritical,
+			Skipped:  true,
+			Comments: []scorecard.TestScoreComment{{Summary: "skipped"}},
+		},
+	}
+
+	return &scorecard.Scorecard{
+		"a": &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: "foo", Namespace: "foofoo"},
+			FileLocation: domain.FileLocation{Name: "deploy/foo bar.yaml", Line: 3},
+			Checks:       checks,
+		},
+		"b": &scorecard.ScoredObject{
+			TypeMeta:     v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta:   v1.ObjectMeta{Name: "bar"},
+			FileLocation: domain.FileLocation{Name: "/abs/bar.yaml", Line: 10},
+			Checks:       checks[1:2],
+		},
+	}
+}
+
+func TestSarifOutput(t *testing.T) {
+	t.Parallel()
+	all, err := io.ReadAll(Output(getTestCard()))
+	assert.Nil(t, err)
+
+	var res sarif.Sarif
+	assert.Nil(t, json.Unmarshal(all, &res))
+	assert.Len(t, res.Runs, 1)
+	run := res.Runs[0]
+
+	score := 47
+	assert.Equal(t, sarif.Properties{Score: &score, Critical: 1, Warning: 2, OK: 1, Skipped: 2}, run.Properties)
+
+	rules := run.Tool.Driver.Rules
+	assert.Len(t, rules, 3)
+	assert.Equal(t, sarif.Rules{
+		ID:                   "test-critical",
+		Name:                 "Test Critical",
+		ShortDescription:     &sarif.Message{Text: "Makes sure that things are not critical"},
+		Help:                 &sarif.Message{Text: "Makes sure that things are not critical"},
+		HelpURI:              "https://kube-score.com/",
+		DefaultConfiguration: &sarif.DefaultConfiguration{Level: "error"},
+		Properties:           &sarif.RulesProperties{SecuritySeverity: "8.0", Tags: []string{"kubernetes", "Deployment"}},
+	}, rules[0])
+	assert.Equal(t, checksDocumentationURL, rules[1].HelpURI)
+	assert.Equal(t, "warning", rules[1].DefaultConfiguration.Level)
+	assert.Equal(t, "5.0", rules[1].Properties.SecuritySeverity)
+
+	// Objects are sorted by key, the skipped and OK checks are not reported
+	results := run.Results
+	assert.Len(t, results, 4)
+	assert.Equal(t, "foo/foofoo apps/v1/Deployment: (a) summary: description", results[0].Message.Text)
+	assert.Equal(t, "error", results[0].Level)
+	assert.Equal(t, 0, results[0].RuleIndex)
+	assert.Equal(t, sarif.ArtifactLocation{URI: "deploy/foo%20bar.yaml", URIBaseID: "%SRCROOT%"}, results[0].Locations[0].PhysicalLocation.ArtifactLocation)
+	assert.Equal(t, 3, results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Len(t, results[0].PartialFingerprints[fingerprintKey], 64)
+	assert.Empty(t, results[0].Suppressions)
+
+	assert.Equal(t, "test-warning", results[1].RuleID)
+	assert.Equal(t, 1, results[1].RuleIndex)
+
+	assert.Equal(t, "test-ignored", results[2].RuleID)
+	assert.Equal(t, "foo/foofoo apps/v1/Deployment: (b) ignored summary", results[2].Message.Text)
+	assert.Equal(t, []sarif.Suppression{{Kind: "inSource", Justification: "Ignored with the kube-score/ignore annotation"}}, results[2].Suppressions)
+
+	// Absolute paths outside of the working directory
+	assert.Equal(t, "test-warning", results[3].RuleID)
+	assert.Equal(t, sarif.ArtifactLocation{URI: "file:///abs/bar.yaml"}, results[3].Locations[0].PhysicalLocation.ArtifactLocation)
+	assert.NotEqual(t, results[1].PartialFingerprints[fingerprintKey], results[3].PartialFingerprints[fingerprintKey])
+}
+
+type testLocation struct{}
+
+func (testLocation) FileLocation() domain.FileLocation {
+	return domain.FileLocation{Name: "deploy/foo.yaml", Line: 1}
+}
+
+func TestSarifSuppressedFingerprint(t *testing.T) {
+	t.Parallel()
+
+	output := func(annotations map[string]string) sarif.Results {
+		card := scorecard.New()
+		o := card.NewObject(v1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}, v1.ObjectMeta{Name: "foo"}, &config.RunConfiguration{UseIgnoreChecksAnnotation: true})
+		ts := scorecard.TestScore{Grade: scorecard.GradeCritical}
+		ts.AddComment("app", "summary", "description")
+		o.Add(ts, domain.Check{Name: "Test Critical", ID: "test-critical"}, testLocation{}, annotations)
+
+		all, err := io.ReadAll(Output(&card))
+		assert.Nil(t, err)
+		var res sarif.Sarif
+		assert.Nil(t, json.Unmarshal(all, &res))
+		assert.Len(t, res.Runs[0].Results, 1)
+		return res.Runs[0].Results[0]
+	}
+
+	reported := output(map[string]string{})
+	suppressed := output(map[string]string{"kube-score/ignore": "test-critical"})
+
+	assert.Empty(t, reported.Suppressions)
+	assert.Len(t, suppressed.Suppressions, 1)
+	assert.Equal(t, reported.PartialFingerprints, suppressed.PartialFingerprints)
+	assert.Equal(t, reported.Message, suppressed.Message)
+}
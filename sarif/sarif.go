@@ -11,9 +11,24 @@ type Sarif struct {
 }
 
 type Rules struct {
-	ID      string `json:"id,omitempty"`
-	Name    string `json:"name,omitempty"`
-	HelpURI string `json:"helpUri,omitempty"`
+	ID                   string                `json:"id,omitempty"`
+	Name                 string                `json:"name,omitempty"`
+	ShortDescription     *Message              `json:"shortDescription,omitempty"`
+	FullDescription      *Message              `json:"fullDescription,omitempty"`
+	Help                 *Message              `json:"help,omitempty"`
+	HelpURI              string                `json:"helpUri,omitempty"`
+	DefaultConfiguration *DefaultConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           *RulesProperties      `json:"properties,omitempty"`
+}
+
+type DefaultConfiguration struct {
+	Level string `json:"level,omitempty"`
+}
+
+type RulesProperties struct {
+	// SecuritySeverity is a score between 0.0 and 10.0, used by GitHub code scanning to show the severity
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
 }
 
 type Driver struct {
@@ -49,6 +64,11 @@ type Invocations struct {
 }
 
 type Properties struct {
+	Score    *int `json:"score,omitempty"`
+	Critical int  `json:"critical,omitempty"`
+	Warning  int  `json:"warning,omitempty"`
+	OK       int  `json:"ok,omitempty"`
+	Skipped  int  `json:"skipped,omitempty"`
 }
 
 type Message struct {
@@ -60,24 +80,25 @@ type Snippet struct {
 }
 
 type Region struct {
-	Snippet   Snippet `json:"snippet,omitempty"`
-	StartLine int     `json:"startLine,omitempty"`
+	Snippet   *Snippet `json:"snippet,omitempty"`
+	StartLine int      `json:"startLine,omitempty"`
 }
 
 type ArtifactLocation struct {
-	URI string `json:"uri,omitempty"`
+	URI       string `json:"uri,omitempty"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
 }
 
 type ContextRegion struct {
-	Snippet   Snippet `json:"snippet,omitempty"`
-	EndLine   int     `json:"endLine,omitempty"`
-	StartLine int     `json:"startLine,omitempty"`
+	Snippet   *Snippet `json:"snippet,omitempty"`
+	EndLine   int      `json:"endLine,omitempty"`
+	StartLine int      `json:"startLine,omitempty"`
 }
 
 type PhysicalLocation struct {
-	Region           Region           `json:"region,omitempty"`
+	Region           *Region          `json:"region,omitempty"`
 	ArtifactLocation ArtifactLocation `json:"artifactLocation,omitempty"`
-	ContextRegion    ContextRegion    `json:"contextRegion,omitempty"`
+	ContextRegion    *ContextRegion   `json:"contextRegion,omitempty"`
 }
 
 type Locations struct {
@@ -89,19 +110,32 @@ type ResultsProperties struct {
 	IssueSeverity   string `json:"issue_severity,omitempty"`
 }
 
+type Suppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
 type Results struct {
-	Message    Message           `json:"message,omitempty"`
-	Level      string            `json:"level,omitempty"`
-	Locations  []Locations       `json:"locations,omitempty"`
-	Properties ResultsProperties `json:"properties,omitempty"`
-	RuleID     string            `json:"ruleId,omitempty"`
-	RuleIndex  int               `json:"ruleIndex,omitempty"`
+	Message             Message           `json:"message,omitempty"`
+	Level               string            `json:"level,omitempty"`
+	Locations           []Locations       `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Suppressions        []Suppression     `json:"suppressions,omitempty"`
+	Properties          ResultsProperties `json:"properties,omitempty"`
+	RuleID              string            `json:"ruleId,omitempty"`
+	RuleIndex           int               `json:"ruleIndex"`
+}
+
+type OriginalURIBaseID struct {
+	URI         string   `json:"uri,omitempty"`
+	Description *Message `json:"description,omitempty"`
 }
 
 type Run struct {
-	Tool        Tool          `json:"tool,omitempty"`
-	Conversion  Conversion    `json:"conversion,omitempty"`
-	Invocations []Invocations `json:"invocations,omitempty"`
-	Properties  Properties    `json:"properties,omitempty"`
-	Results     []Results     `json:"results,omitempty"`
+	Tool               Tool                         `json:"tool,omitempty"`
+	OriginalURIBaseIDs map[string]OriginalURIBaseID `json:"originalUriBaseIds,omitempty"`
+	Conversion         Conversion                   `json:"conversion,omitempty"`
+	Invocations        []Invocations                `json:"invocations,omitempty"`
+	Properties         Properties                   `json:"properties,omitempty"`
+	Results            []Results                    `json:"results,omitempty"`
 }
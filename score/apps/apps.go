@@ -23,6 +23,12 @@ func Register(allChecks *checks.Checks, allHPAs []ks.HpaTargeter, allServices []
 
 	allChecks.RegisterDeploymentCheck("Deployment Pod Selector labels match template metadata labels", "Ensure the StatefulSet selector labels match the template metadata labels.", deploymentSelectorLabelsMatching)
 	allChecks.RegisterStatefulSetCheck("StatefulSet Pod Selector labels match template metadata labels", "Ensure the StatefulSet selector labels match the template metadata labels.", statefulSetSelectorLabelsMatching)
+
+	allChecks.RegisterStatefulSetCheck("StatefulSet Update Strategy", "Makes sure that StatefulSets use the RollingUpdate strategy, and that a partition is not left from a staged rollout", statefulSetUpdateStrategy)
+	allChecks.RegisterStatefulSetCheck("StatefulSet Pod Management Policy", "Makes sure that StatefulSets have a valid podManagementPolicy", statefulSetPodManagementPolicy)
+	allChecks.RegisterStatefulSetCheck("StatefulSet PersistentVolumeClaim Retention Policy", "Makes sure that the persistentVolumeClaimRetentionPolicy of StatefulSets does not unexpectedly delete data", statefulSetPVCRetentionPolicy)
+	allChecks.RegisterStatefulSetCheck("StatefulSet VolumeClaimTemplates StorageClass", "Makes sure that all volumeClaimTemplates of StatefulSets have a storageClassName", statefulSetVolumeClaimTemplatesStorageClass)
+	allChecks.RegisterDaemonSetCheck("DaemonSet Update Strategy", "Makes sure that DaemonSets use the RollingUpdate strategy, with safe maxUnavailable and maxSurge values", daemonSetUpdateStrategy)
 }
 
 func hpaDeploymentNoReplicas(allHPAs []ks.HpaTargeter) func(deployment appsv1.Deployment) (scorecard.TestScore, error) {
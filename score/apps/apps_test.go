@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -363,6 +364,18 @@ func (d hpav1) HpaTarget() autoscalingv1.CrossVersionObjectReference {
 	return d.Spec.ScaleTargetRef
 }
 
+func (d hpav1) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
+
+func (d hpav1) Metrics() []autoscalingv2.MetricSpec {
+	return nil
+}
+
+func (d hpav1) Behavior() *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	return nil
+}
+
 func (hpav1) FileLocation() ks.FileLocation {
 	return ks.FileLocation{}
 }
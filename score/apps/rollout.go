@@ -0,0 +1,180 @@
+package apps
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// A DaemonSet rolling update with a maxUnavailable of this percentage or more updates most nodes at once
+const maxDaemonSetUnavailablePercent = 50
+
+// statefulSetUpdateStrategy checks that the StatefulSet is updated automatically, and that a partition is not left from a staged rollout
+func statefulSetUpdateStrategy(statefulset appsv1.StatefulSet) (score scorecard.TestScore, err error) {
+	strategy := statefulset.Spec.UpdateStrategy
+
+	if strategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		score.Grade = scorecard.GradeWarning
+		score.AddCommentWithURL("", "StatefulSet uses the OnDelete update strategy",
+			"Pods are only updated when they are manually deleted, and changes to the pod template are not rolled out. Set .spec.updateStrategy.type to RollingUpdate.",
+			"https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#update-strategies")
+		return
+	}
+
+	if strategy.RollingUpdate != nil && strategy.RollingUpdate.Partition != nil && *strategy.RollingUpdate.Partition > 0 {
+		score.Grade = scorecard.GradeWarning
+		score.AddCommentWithURL("", fmt.Sprintf("The rolling update has a partition of %d", *strategy.RollingUpdate.Partition),
+			"Pods with an ordinal lower than the partition are not updated. A partition is used for staged rollouts, and should be set back to 0 when the rollout is done.",
+			"https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#partitions")
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+	return
+}
+
+// statefulSetPodManagementPolicy checks that the podManagementPolicy is explicitly set
+func statefulSetPodManagementPolicy(statefulset appsv1.StatefulSet) (score scorecard.TestScore, err error) {
+	switch statefulset.Spec.PodManagementPolicy {
+	case appsv1.OrderedReadyPodManagement, appsv1.ParallelPodManagement:
+		score.Grade = scorecard.GradeAllOK
+	case "":
+		score.Grade = scorecard.GradeAlmostOK
+		score.AddCommentWithURL("", "podManagementPolicy is not set, OrderedReady is assumed",
+			"Pods are created and deleted one at a time, and a pod that is not ready blocks scaling. If the pods don't depend on each other, Parallel makes scaling faster.",
+			"https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#pod-management-policies")
+	default:
+		score.Grade = scorecard.GradeCritical
+		score.AddComment("", fmt.Sprintf("Invalid podManagementPolicy %q", statefulset.Spec.PodManagementPolicy),
+			"Valid podManagementPolicy settings are OrderedReady or Parallel")
+	}
+	return
+}
+
+// statefulSetPVCRetentionPolicy checks what happens to the PersistentVolumeClaims when the StatefulSet is deleted or scaled down
+func statefulSetPVCRetentionPolicy(statefulset appsv1.StatefulSet) (score scorecard.TestScore, err error) {
+	if len(statefulset.Spec.VolumeClaimTemplates) == 0 {
+		score.Grade = scorecard.GradeAllOK
+		score.Skipped = true
+		score.AddComment("", "Skipped because the StatefulSet has no volumeClaimTemplates", "")
+		return
+	}
+
+	policy := statefulset.Spec.PersistentVolumeClaimRetentionPolicy
+	if policy == nil {
+		score.Grade = scorecard.GradeAlmostOK
+		score.AddCommentWithURL("", "persistentVolumeClaimRetentionPolicy is not set, Retain is assumed",
+			"The PersistentVolumeClaims are kept when the StatefulSet is deleted or scaled down, and need to be deleted manually. Set the policy to make the intent explicit.",
+			"https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#persistentvolumeclaim-retention")
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+	if policy.WhenDeleted == appsv1.DeletePersistentVolumeClaimRetentionPolicyType {
+		score.Grade = scorecard.GradeWarning
+		score.AddCommentWithURL("", "The PersistentVolumeClaims are deleted together with the StatefulSet",
+			"All data is lost if the StatefulSet is deleted, for example when it's recreated to change an immutable field. Make sure that this is intended.",
+			"https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#persistentvolumeclaim-retention")
+	}
+	return
+}
+
+// statefulSetVolumeClaimTemplatesStorageClass checks that all volumeClaimTemplates have a storageClassName
+func statefulSetVolumeClaimTemplatesStorageClass(statefulset appsv1.StatefulSet) (score scorecard.TestScore, err error) {
+	if len(statefulset.Spec.VolumeClaimTemplates) == 0 {
+		score.Grade = scorecard.GradeAllOK
+		score.Skipped = true
+		score.AddComment("", "Skipped because the StatefulSet has no volumeClaimTemplates", "")
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+	for _, template := range statefulset.Spec.VolumeClaimTemplates {
+		if template.Spec.StorageClassName == nil {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(template.Name, "The volumeClaimTemplate has no storageClassName",
+				"The PersistentVolumeClaims get the default StorageClass of the cluster, which can differ between clusters, and the StorageClass of a claim can not be changed later. Set storageClassName.")
+		}
+	}
+	return
+}
+
+// daemonSetUpdateStrategy checks that the DaemonSet is updated automatically, and that a rolling update is safe
+func daemonSetUpdateStrategy(daemonset appsv1.DaemonSet) (score scorecard.TestScore, err error) {
+	strategy := daemonset.Spec.UpdateStrategy
+
+	if strategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		score.Grade = scorecard.GradeWarning
+		score.AddCommentWithURL("", "DaemonSet uses the OnDelete update strategy",
+			"Pods are only updated when they are manually deleted, and changes to the pod template are not rolled out. Set .spec.updateStrategy.type to RollingUpdate.",
+			"https://kubernetes.io/docs/tasks/manage-daemon/update-daemon-set/#daemonset-update-strategy")
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+
+	rollingUpdate := strategy.RollingUpdate
+	if rollingUpdate == nil {
+		return
+	}
+
+	maxUnavailable := intstr.FromInt32(1)
+	if rollingUpdate.MaxUnavailable != nil {
+		maxUnavailable = *rollingUpdate.MaxUnavailable
+	}
+	maxSurge := intstr.FromInt32(0)
+	if rollingUpdate.MaxSurge != nil {
+		maxSurge = *rollingUpdate.MaxSurge
+	}
+
+	if isZero(maxUnavailable) && isZero(maxSurge) {
+		score.Grade = scorecard.GradeCritical
+		score.AddComment("", "maxUnavailable and maxSurge are both 0",
+			"The rolling update can never make progress. Set maxUnavailable or maxSurge to a value larger than 0.")
+		return
+	}
+
+	if percent, ok := percentValue(maxUnavailable); ok && percent >= maxDaemonSetUnavailablePercent {
+		score.LowerGrade(scorecard.GradeWarning)
+		score.AddComment("", fmt.Sprintf("maxUnavailable is %s", maxUnavailable.String()),
+			"The pods on a large part of the nodes are unavailable at the same time during a rolling update, and a broken version affects most nodes before the rollout can be stopped.")
+	}
+
+	if !isZero(maxSurge) {
+		for _, container := range daemonset.Spec.Template.Spec.Containers {
+			for _, port := range container.Ports {
+				if port.HostPort != 0 {
+					score.LowerGrade(scorecard.GradeCritical)
+					score.AddComment(container.Name, fmt.Sprintf("maxSurge is used together with the hostPort %d", port.HostPort),
+						"With maxSurge, the new pod is started on the node before the old pod is stopped. The new pod can not be scheduled since the hostPort is already in use, and the rollout is stuck. Use maxUnavailable instead.")
+				}
+			}
+		}
+	}
+
+	return
+}
+
+func isZero(v intstr.IntOrString) bool {
+	if v.Type == intstr.Int {
+		return v.IntVal == 0
+	}
+	percent, ok := percentValue(v)
+	return ok && percent == 0
+}
+
+func percentValue(v intstr.IntOrString) (int, bool) {
+	if v.Type != intstr.String || !strings.HasSuffix(v.StrVal, "%") {
+		return 0, false
+	}
+	percent, err := strconv.Atoi(strings.TrimSuffix(v.StrVal, "%"))
+	if err != nil {
+		return 0, false
+	}
+	return percent, true
+}
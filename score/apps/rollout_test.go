@@ -0,0 +1,45 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestDaemonSetUpdateStrategyRollingUpdate(t *testing.T) {
+	t.Parallel()
+
+	intOrString := func(v intstr.IntOrString) *intstr.IntOrString { return &v }
+
+	tests := []struct {
+		name           string
+		maxUnavailable *intstr.IntOrString
+		maxSurge       *intstr.IntOrString
+		expected       scorecard.Grade
+	}{
+		{"defaults", nil, nil, scorecard.GradeAllOK},
+		{"both zero", intOrString(intstr.FromInt32(0)), intOrString(intstr.FromString("0%")), scorecard.GradeCritical},
+		{"surge only", intOrString(intstr.FromInt32(0)), intOrString(intstr.FromString("10%")), scorecard.GradeAllOK},
+		{"low percentage", intOrString(intstr.FromString("10%")), nil, scorecard.GradeAllOK},
+		{"high percentage", intOrString(intstr.FromString("50%")), nil, scorecard.GradeWarning},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: tc.maxUnavailable,
+					MaxSurge:       tc.maxSurge,
+				},
+			}}}
+			score, err := daemonSetUpdateStrategy(ds)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, score.Grade)
+		})
+	}
+}
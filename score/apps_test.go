@@ -123,3 +123,58 @@ func TestStatefulsetTemplateIgnoresNotIgnoredWhenFlagDisabled(t *testing.T) {
 	}, "Container Image Tag")
 	assert.False(t, skipped)
 }
+
+func TestStatefulSetUpdateStrategy(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "statefulset-rollout-ok.yaml", "StatefulSet Update Strategy", scorecard.GradeAllOK)
+
+	comments := testExpectedScore(t, "statefulset-rollout-ondelete.yaml", "StatefulSet Update Strategy", scorecard.GradeWarning)
+	assert.Equal(t, "StatefulSet uses the OnDelete update strategy", comments[0].Summary)
+
+	comments = testExpectedScore(t, "statefulset-rollout-staged.yaml", "StatefulSet Update Strategy", scorecard.GradeWarning)
+	assert.Equal(t, "The rolling update has a partition of 2", comments[0].Summary)
+}
+
+func TestStatefulSetPodManagementPolicy(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "statefulset-rollout-ok.yaml", "StatefulSet Pod Management Policy", scorecard.GradeAllOK)
+	testExpectedScore(t, "statefulset-rollout-staged.yaml", "StatefulSet Pod Management Policy", scorecard.GradeAlmostOK)
+
+	comments := testExpectedScore(t, "statefulset-rollout-ondelete.yaml", "StatefulSet Pod Management Policy", scorecard.GradeCritical)
+	assert.Equal(t, `Invalid podManagementPolicy "Random"`, comments[0].Summary)
+}
+
+func TestStatefulSetPVCRetentionPolicy(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "statefulset-rollout-ok.yaml", "StatefulSet PersistentVolumeClaim Retention Policy", scorecard.GradeAllOK)
+	testExpectedScore(t, "statefulset-rollout-staged.yaml", "StatefulSet PersistentVolumeClaim Retention Policy", scorecard.GradeWarning)
+	assert.True(t, wasSkipped(t, []ks.NamedReader{testFile("statefulset-rollout-ondelete.yaml")}, nil, nil, "StatefulSet PersistentVolumeClaim Retention Policy"))
+}
+
+func TestStatefulSetVolumeClaimTemplatesStorageClass(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "statefulset-rollout-ok.yaml", "StatefulSet VolumeClaimTemplates StorageClass", scorecard.GradeAllOK)
+
+	comments := testExpectedScore(t, "statefulset-rollout-staged.yaml", "StatefulSet VolumeClaimTemplates StorageClass", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "data", comments[0].Path)
+	assert.Equal(t, "The volumeClaimTemplate has no storageClassName", comments[0].Summary)
+}
+
+func TestDaemonSetUpdateStrategy(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "daemonset-appsv1.yaml", "DaemonSet Update Strategy", scorecard.GradeAllOK)
+
+	comments := testExpectedScore(t, "daemonset-rollout-ondelete.yaml", "DaemonSet Update Strategy", scorecard.GradeWarning)
+	assert.Equal(t, "DaemonSet uses the OnDelete update strategy", comments[0].Summary)
+
+	comments = testExpectedScore(t, "daemonset-rollout-unavailable.yaml", "DaemonSet Update Strategy", scorecard.GradeWarning)
+	assert.Equal(t, "maxUnavailable is 100%", comments[0].Summary)
+
+	comments = testExpectedScore(t, "daemonset-rollout-surge-hostport.yaml", "DaemonSet Update Strategy", scorecard.GradeCritical)
+	assert.Equal(t, []scorecard.TestScoreComment{{
+		Path:        "agent",
+		Summary:     "maxSurge is used together with the hostPort 9100",
+		Description: "With maxSurge, the new pod is started on the node before the old pod is stopped. The new pod can not be scheduled since the hostPort is already in use, and the rollout is stuck. Use maxUnavailable instead.",
+	}}, comments)
+}
@@ -6,6 +6,7 @@ import (
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 )
@@ -25,10 +26,13 @@ func New(cnf *Config) *Checks {
 		metas:                    make(map[string]GenCheck[ks.BothMeta]),
 		pods:                     make(map[string]GenCheck[ks.PodSpecer]),
 		services:                 make(map[string]GenCheck[corev1.Service]),
+		configMaps:               make(map[string]GenCheck[corev1.ConfigMap]),
 		statefulsets:             make(map[string]GenCheck[appsv1.StatefulSet]),
 		deployments:              make(map[string]GenCheck[appsv1.Deployment]),
 		networkpolicies:          make(map[string]GenCheck[networkingv1.NetworkPolicy]),
 		ingresses:                make(map[string]GenCheck[ks.Ingress]),
+		jobs:                     make(map[string]GenCheck[batchv1.Job]),
+		daemonsets:               make(map[string]GenCheck[appsv1.DaemonSet]),
 		cronjobs:                 make(map[string]GenCheck[ks.CronJob]),
 		horizontalPodAutoscalers: make(map[string]GenCheck[ks.HpaTargeter]),
 		poddisruptionbudgets:     make(map[string]GenCheck[ks.PodDisruptionBudget]),
@@ -69,10 +73,13 @@ type Checks struct {
 	metas                    map[string]GenCheck[ks.BothMeta]
 	pods                     map[string]GenCheck[ks.PodSpecer]
 	services                 map[string]GenCheck[corev1.Service]
+	configMaps               map[string]GenCheck[corev1.ConfigMap]
 	statefulsets             map[string]GenCheck[appsv1.StatefulSet]
 	deployments              map[string]GenCheck[appsv1.Deployment]
 	networkpolicies          map[string]GenCheck[networkingv1.NetworkPolicy]
 	ingresses                map[string]GenCheck[ks.Ingress]
+	jobs                     map[string]GenCheck[batchv1.Job]
+	daemonsets               map[string]GenCheck[appsv1.DaemonSet]
 	cronjobs                 map[string]GenCheck[ks.CronJob]
 	horizontalPodAutoscalers map[string]GenCheck[ks.HpaTargeter]
 	poddisruptionbudgets     map[string]GenCheck[ks.PodDisruptionBudget]
@@ -131,6 +138,18 @@ func (c *Checks) HorizontalPodAutoscalers() map[string]GenCheck[ks.HpaTargeter]
 	return c.horizontalPodAutoscalers
 }
 
+func (c *Checks) RegisterJobCheck(name, comment string, fn CheckFunc[batchv1.Job]) {
+	reg(c, "Job", name, comment, false, fn, c.jobs)
+}
+
+func (c *Checks) RegisterOptionalJobCheck(name, comment string, fn CheckFunc[batchv1.Job]) {
+	reg(c, "Job", name, comment, true, fn, c.jobs)
+}
+
+func (c *Checks) Jobs() map[string]GenCheck[batchv1.Job] {
+	return c.jobs
+}
+
 func (c *Checks) RegisterCronJobCheck(name, comment string, fn CheckFunc[ks.CronJob]) {
 	reg(c, "CronJob", name, comment, false, fn, c.cronjobs)
 }
@@ -155,6 +174,18 @@ func (c *Checks) StatefulSets() map[string]GenCheck[appsv1.StatefulSet] {
 	return c.statefulsets
 }
 
+func (c *Checks) RegisterDaemonSetCheck(name, comment string, fn CheckFunc[appsv1.DaemonSet]) {
+	reg(c, "DaemonSet", name, comment, false, fn, c.daemonsets)
+}
+
+func (c *Checks) RegisterOptionalDaemonSetCheck(name, comment string, fn CheckFunc[appsv1.DaemonSet]) {
+	reg(c, "DaemonSet", name, comment, true, fn, c.daemonsets)
+}
+
+func (c *Checks) DaemonSets() map[string]GenCheck[appsv1.DaemonSet] {
+	return c.daemonsets
+}
+
 func (c *Checks) RegisterDeploymentCheck(name, comment string, fn CheckFunc[appsv1.Deployment]) {
 	reg(c, "Deployment", name, comment, false, fn, c.deployments)
 }
@@ -211,6 +242,18 @@ func (c *Checks) Services() map[string]GenCheck[corev1.Service] {
 	return c.services
 }
 
+func (c *Checks) RegisterConfigMapCheck(name, comment string, fn CheckFunc[corev1.ConfigMap]) {
+	reg(c, "ConfigMap", name, comment, false, fn, c.configMaps)
+}
+
+func (c *Checks) RegisterOptionalConfigMapCheck(name, comment string, fn CheckFunc[corev1.ConfigMap]) {
+	reg(c, "ConfigMap", name, comment, true, fn, c.configMaps)
+}
+
+func (c *Checks) ConfigMaps() map[string]GenCheck[corev1.ConfigMap] {
+	return c.configMaps
+}
+
 func (c *Checks) All() []ks.Check {
 	return c.all
 }
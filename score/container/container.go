@@ -2,20 +2,29 @@ package container
 
 import (
 	"fmt"
-	"strings"
 
+	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/score/internal"
 	"github.com/zegl/kube-score/scorecard"
 	corev1 "k8s.io/api/core/v1"
 )
 
-func Register(allChecks *checks.Checks, ignoreContainerCpuLimitRequirement, ignoreContainerMemoryLimitRequirement bool) {
+func Register(allChecks *checks.Checks, ignoreContainerCpuLimitRequirement, ignoreContainerMemoryLimitRequirement bool, imagePolicy config.ImagePolicy, resourcePolicy config.ResourcePolicy, minQOSClass string) {
 	allChecks.RegisterPodCheck("Container Resources", `Makes sure that all pods have resource limits and requests set. The --ignore-container-cpu-limit flag can be used to disable the requirement of having a CPU limit`, containerResources(!ignoreContainerCpuLimitRequirement, !ignoreContainerMemoryLimitRequirement))
+	allChecks.RegisterPodCheck("Container Resource Units", `Makes sure that CPU and memory quantities use reasonable units, such as 512Mi instead of 512m. The thresholds are configured with the --cpu-unit-threshold and --memory-unit-threshold flags`, containerResourceUnits(resourcePolicy.CPUUnitThreshold, resourcePolicy.MemoryUnitThreshold))
+	allChecks.RegisterPodCheck("Container Resource Limit Request Ratio", `Makes sure that limits are not too much higher than the requests. Configured with the --max-limit-request-ratio flag`, containerResourceLimitRequestRatio(resourcePolicy.MaxLimitRequestRatio))
+	allChecks.RegisterPodCheck("Container Resource Bounds", `Makes sure that container CPU and memory are within the bounds configured with the --min-container-cpu, --max-container-cpu, --min-container-memory and --max-container-memory flags`, containerResourceBounds(resourcePolicy))
+	allChecks.RegisterPodCheck("Pod Resources Fit Node", `Makes sure that the total resources of a pod fits on a node with the size configured with the --node-cpu and --node-memory flags`, podResourcesFitNode(resourcePolicy.NodeCPU, resourcePolicy.NodeMemory))
+	allChecks.RegisterPodCheck("Pod QoS Class", `Makes sure that the QoS class of the pod is at least the minimum set with the kube-score/min-qos label or the --min-qos-class flag`, podQOSClass(minQOSClass))
 	allChecks.RegisterOptionalPodCheck("Container Resource Requests Equal Limits", `Makes sure that all pods have the same requests as limits on resources set.`, containerResourceRequestsEqualLimits)
 	allChecks.RegisterOptionalPodCheck("Container CPU Requests Equal Limits", `Makes sure that all pods have the same CPU requests as limits set.`, containerCPURequestsEqualLimits)
 	allChecks.RegisterOptionalPodCheck("Container Memory Requests Equal Limits", `Makes sure that all pods have the same memory requests as limits set.`, containerMemoryRequestsEqualLimits)
 	allChecks.RegisterPodCheck("Container Image Tag", `Makes sure that a explicit non-latest tag is used`, containerImageTag)
+	allChecks.RegisterOptionalPodCheck("Container Image Digest", `Makes sure that all images are pinned to a digest`, containerImageDigest)
+	allChecks.RegisterPodCheck("Container Image Registry", `Makes sure that all images are from an allowed registry or repository. Configured with the --allowed-image-registry and --denied-image-registry flags`, containerImageRegistry(imagePolicy.AllowedRegistries, imagePolicy.DeniedRegistries))
+	allChecks.RegisterPodCheck("Container Image Mutable Tag", `Makes sure that no image uses a mutable tag. Configured with the --mutable-image-tag flag`, containerImageMutableTag(imagePolicy.MutableTags))
 	allChecks.RegisterPodCheck("Container Image Pull Policy", `Makes sure that the pullPolicy is set to Always. This makes sure that imagePullSecrets are always validated.`, containerImagePullPolicy)
 	allChecks.RegisterPodCheck("Container Ephemeral Storage Request and Limit", "Makes sure all pods have ephemeral-storage requests and limits set", containerStorageEphemeralRequestAndLimit)
 	allChecks.RegisterOptionalPodCheck("Container Ephemeral Storage Request Equals Limit", "Make sure all pods have matching ephemeral-storage requests and limits", containerStorageEphemeralRequestEqualsLimit)
@@ -152,8 +161,7 @@ func containerImageTag(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
 	hasTagLatest := false
 
 	for _, container := range allContainers {
-		tag := containerTag(container.Image)
-		if tag == "" || tag == "latest" {
+		if hasMutableDefaultTag(container.Image) {
 			score.AddComment(container.Name, "Image with latest tag", "Using a fixed tag is recommended to avoid accidental upgrades")
 			hasTagLatest = true
 		}
@@ -179,11 +187,9 @@ func containerImagePullPolicy(ps ks.PodSpecer) (score scorecard.TestScore, err e
 	score.Grade = scorecard.GradeAllOK
 
 	for _, container := range allContainers {
-		tag := containerTag(container.Image)
-
 		// If the pull policy is not set, and the tag is either empty or latest
 		// kubernetes will default to always pull the image
-		if container.ImagePullPolicy == corev1.PullPolicy("") && (tag == "" || tag == "latest") {
+		if container.ImagePullPolicy == corev1.PullPolicy("") && hasMutableDefaultTag(container.Image) {
 			continue
 		}
 
@@ -197,15 +203,13 @@ func containerImagePullPolicy(ps ks.PodSpecer) (score scorecard.TestScore, err e
 	return
 }
 
-// containerTag returns the image tag
-// An empty string is returned if the image has no tag
-func containerTag(image string) string {
-	imageParts := strings.Split(image, ":")
-	if len(imageParts) > 1 {
-		imageVersion := imageParts[len(imageParts)-1]
-		return imageVersion
+// hasMutableDefaultTag returns true if the image has no tag or digest, or uses the "latest" tag
+func hasMutableDefaultTag(image string) bool {
+	ref := internal.ParseImageReference(image)
+	if ref.Digest != "" {
+		return false
 	}
-	return ""
+	return ref.Tag == "" || ref.Tag == "latest"
 }
 
 func containerStorageEphemeralRequestAndLimit(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
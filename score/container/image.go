@@ -0,0 +1,110 @@
+package container
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+// containerImageDigest checks that all images are pinned with a sha256 digest
+func containerImageDigest(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range internal.AllContainers(ps.GetPodTemplateSpec().Spec) {
+		ref := internal.ParseImageReference(container.Image)
+		if !strings.HasPrefix(ref.Digest, "sha256:") {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name, "Image is not pinned to a digest",
+				fmt.Sprintf("Pinning images to a digest (%s@sha256:...) makes sure that the same image is used every time, even if the tag is moved", ref.Name()))
+		}
+	}
+
+	return
+}
+
+// imageMatchesRegistry returns true if the image is in the registry or repository
+// "gcr.io" matches all images in gcr.io, "docker.io/library" matches all official Docker Hub images
+func imageMatchesRegistry(ref internal.ImageReference, registry string) bool {
+	registry = strings.TrimSuffix(registry, "/")
+	name := ref.Name()
+	return name == registry || strings.HasPrefix(name, registry+"/")
+}
+
+func imageMatchesAnyRegistry(ref internal.ImageReference, registries []string) (string, bool) {
+	for _, r := range registries {
+		if imageMatchesRegistry(ref, r) {
+			return r, true
+		}
+	}
+	return "", false
+}
+
+// containerImageRegistry checks that all images are in an allowed registry, and not in a denied registry
+func containerImageRegistry(allowedRegistries, deniedRegistries []string) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		if len(allowedRegistries) == 0 && len(deniedRegistries) == 0 {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because no allowed or denied image registries are configured", "")
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		for _, container := range internal.AllContainers(ps.GetPodTemplateSpec().Spec) {
+			ref := internal.ParseImageReference(container.Image)
+
+			if denied, ok := imageMatchesAnyRegistry(ref, deniedRegistries); ok {
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.Name, fmt.Sprintf("Image %s is from a denied registry", container.Image),
+					fmt.Sprintf("Images from %s are not allowed", denied))
+				continue
+			}
+
+			if _, ok := imageMatchesAnyRegistry(ref, allowedRegistries); len(allowedRegistries) > 0 && !ok {
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.Name, fmt.Sprintf("Image %s is not from an allowed registry", container.Image),
+					fmt.Sprintf("Images must be from one of: %s", strings.Join(allowedRegistries, ", ")))
+			}
+		}
+
+		return
+	}
+}
+
+// containerImageMutableTag checks that no image is using a tag that matches one of the mutable tag patterns
+// Images that are pinned with a digest are always accepted, as the tag is ignored when pulling the image
+func containerImageMutableTag(mutableTags []string) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		if len(mutableTags) == 0 {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because no mutable image tags are configured", "")
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		for _, container := range internal.AllContainers(ps.GetPodTemplateSpec().Spec) {
+			ref := internal.ParseImageReference(container.Image)
+			if ref.Tag == "" || ref.Digest != "" {
+				continue
+			}
+
+			for _, pattern := range mutableTags {
+				if matched, _ := path.Match(pattern, ref.Tag); matched {
+					score.Grade = scorecard.GradeCritical
+					score.AddComment(container.Name, fmt.Sprintf("Image with mutable tag %s", ref.Tag),
+						"Using a fixed tag, such as a version number, is recommended to avoid accidental upgrades")
+					break
+				}
+			}
+		}
+
+		return
+	}
+}
@@ -0,0 +1,61 @@
+package container
+
+import (
+	"fmt"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/scorecard"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const minQOSClassLabel = "kube-score/min-qos"
+
+// podQOSClass checks that the QoS class of the pod is at least the minimum class
+// The minimum is set with the kube-score/min-qos label on the object or the pod template, or with the configured default.
+func podQOSClass(defaultMinClass string) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		minClass, source := defaultMinClass, "the configuration"
+		if v, ok := ps.GetPodTemplateSpec().Labels[minQOSClassLabel]; ok {
+			minClass, source = v, "the "+minQOSClassLabel+" label on the pod template"
+		}
+		if v, ok := ps.GetObjectMeta().Labels[minQOSClassLabel]; ok {
+			minClass, source = v, "the "+minQOSClassLabel+" label"
+		}
+
+		if minClass == "" {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because no minimum QoS class is set", fmt.Sprintf("Set the minimum with the %s label or the --min-qos-class flag", minQOSClassLabel))
+			return
+		}
+
+		minRank, ok := internal.QOSClassRank(corev1.PodQOSClass(minClass))
+		if !ok {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("", fmt.Sprintf("Unknown QoS class %q", minClass),
+				fmt.Sprintf("The minimum QoS class is set by %s, and must be one of Guaranteed, Burstable or BestEffort", source))
+			return
+		}
+
+		class := internal.PodQOSClass(ps.GetPodTemplateSpec().Spec)
+		rank, _ := internal.QOSClassRank(class)
+		if rank < minRank {
+			score.Grade = scorecard.GradeCritical
+			score.AddCommentWithURL("", fmt.Sprintf("The pod has the QoS class %s, the minimum is %s", class, minClass),
+				fmt.Sprintf("The minimum is set by %s. %s", source, qosClassAdvice(corev1.PodQOSClass(minClass))),
+				"https://kubernetes.io/docs/concepts/workloads/pods/pod-qos/")
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+}
+
+func qosClassAdvice(minClass corev1.PodQOSClass) string {
+	if minClass == corev1.PodQOSGuaranteed {
+		return "Set CPU and memory requests and limits on all containers, including init containers, with the requests equal to the limits."
+	}
+	return "Set a CPU or memory request or limit on at least one container."
+}
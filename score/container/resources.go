@@ -0,0 +1,198 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/scorecard"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type namedResource struct {
+	name corev1.ResourceName
+	list corev1.ResourceList
+	kind string
+}
+
+func requestsAndLimits(container corev1.Container) []namedResource {
+	return []namedResource{
+		{corev1.ResourceCPU, container.Resources.Requests, "requests"},
+		{corev1.ResourceCPU, container.Resources.Limits, "limits"},
+		{corev1.ResourceMemory, container.Resources.Requests, "requests"},
+		{corev1.ResourceMemory, container.Resources.Limits, "limits"},
+	}
+}
+
+// containerResourceUnits checks for resource quantities that are very likely to use the wrong unit,
+// such as "memory: 512m" (512 millibytes) or "cpu: 1000" (1000 cores)
+// Whole CPU quantities of at least cpuThreshold and memory quantities below memoryThreshold are reported, a zero threshold is not checked.
+func containerResourceUnits(cpuThreshold, memoryThreshold resource.Quantity) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		score.Grade = scorecard.GradeAllOK
+
+		for _, container := range internal.AllContainers(ps.GetPodTemplateSpec().Spec) {
+			for _, r := range requestsAndLimits(container) {
+				q, ok := r.list[r.name]
+				if !ok || q.IsZero() {
+					continue
+				}
+				field := fmt.Sprintf("resources.%s.%s", r.kind, r.name)
+
+				switch r.name {
+				case corev1.ResourceMemory:
+					if q.MilliValue()%1000 != 0 {
+						// The original suffix is not kept after parsing, but only decimal quantities are formatted with the "m" suffix
+						if q.Format == resource.DecimalSI && strings.HasSuffix(q.String(), "m") {
+							score.LowerGrade(scorecard.GradeCritical)
+							score.AddComment(container.Name, fmt.Sprintf("%s is set to %s millibytes", field, q.String()),
+								"The \"m\" suffix means milli, and not mega. Use Mi or M to set the value in mebibytes or megabytes, for example 512Mi.")
+						} else {
+							score.LowerGrade(scorecard.GradeWarning)
+							score.AddComment(container.Name, fmt.Sprintf("%s is not a whole number of bytes", field),
+								"Memory quantities are rounded up to a whole number of bytes. Use a smaller unit to set an exact value, for example 1126Ki instead of 1.1Ki.")
+						}
+					} else if !memoryThreshold.IsZero() && q.Cmp(memoryThreshold) < 0 {
+						score.LowerGrade(scorecard.GradeCritical)
+						score.AddComment(container.Name, fmt.Sprintf("%s is set to %s bytes", field, q.String()),
+							"Memory quantities without a suffix are in bytes. Use Mi or Gi to set the value in mebibytes or gibibytes, for example 512Mi.")
+					}
+				case corev1.ResourceCPU:
+					if !cpuThreshold.IsZero() && q.MilliValue()%1000 == 0 && q.Cmp(cpuThreshold) >= 0 {
+						score.LowerGrade(scorecard.GradeWarning)
+						score.AddComment(container.Name, fmt.Sprintf("%s is set to %s cores", field, q.String()),
+							fmt.Sprintf("CPU quantities without a suffix are in cores. Use the \"m\" suffix to set the value in millicores, for example %dm.", q.Value()))
+					}
+				}
+			}
+		}
+
+		return
+	}
+}
+
+// containerResourceLimitRequestRatio checks that the limit of a resource is not more than maxRatio times the request
+func containerResourceLimitRequestRatio(maxRatio float64) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		if maxRatio <= 0 {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because no max limit to request ratio is configured", "")
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		for _, container := range internal.AllContainers(ps.GetPodTemplateSpec().Spec) {
+			for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+				request, hasRequest := container.Resources.Requests[name]
+				limit, hasLimit := container.Resources.Limits[name]
+				if !hasRequest || !hasLimit || request.IsZero() {
+					continue
+				}
+
+				ratio := limit.AsApproximateFloat64() / request.AsApproximateFloat64()
+				if ratio > maxRatio {
+					score.LowerGrade(scorecard.GradeWarning)
+					score.AddComment(container.Name, fmt.Sprintf("The %s limit is %.1f times the request", name, ratio),
+						fmt.Sprintf("The limit (%s) should be at most %g times the request (%s). A large difference makes the node overcommitted, and the container is likely to be throttled or evicted under load.", limit.String(), maxRatio, request.String()))
+				}
+			}
+		}
+
+		return
+	}
+}
+
+// containerResourceBounds checks that the requests are above the configured minimum, and that the limits are below the configured maximum
+// If a limit is not set, the request is compared to the maximum.
+func containerResourceBounds(policy config.ResourcePolicy) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	type bounds struct {
+		name     corev1.ResourceName
+		min, max resource.Quantity
+	}
+	allBounds := []bounds{
+		{corev1.ResourceCPU, policy.MinContainerCPU, policy.MaxContainerCPU},
+		{corev1.ResourceMemory, policy.MinContainerMemory, policy.MaxContainerMemory},
+	}
+
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		if policy.MinContainerCPU.IsZero() && policy.MaxContainerCPU.IsZero() &&
+			policy.MinContainerMemory.IsZero() && policy.MaxContainerMemory.IsZero() {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because no container resource bounds are configured", "")
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		for _, container := range internal.AllContainers(ps.GetPodTemplateSpec().Spec) {
+			for _, b := range allBounds {
+				request, hasRequest := container.Resources.Requests[b.name]
+				if !b.min.IsZero() && hasRequest && request.Cmp(b.min) < 0 {
+					score.LowerGrade(scorecard.GradeWarning)
+					score.AddComment(container.Name, fmt.Sprintf("The %s request is below the minimum", b.name),
+						fmt.Sprintf("The request (%s) is lower than the configured minimum of %s", request.String(), b.min.String()))
+				}
+
+				upper, hasUpper := container.Resources.Limits[b.name]
+				if !hasUpper {
+					upper, hasUpper = request, hasRequest
+				}
+				if !b.max.IsZero() && hasUpper && upper.Cmp(b.max) > 0 {
+					score.LowerGrade(scorecard.GradeWarning)
+					score.AddComment(container.Name, fmt.Sprintf("The %s limit is above the maximum", b.name),
+						fmt.Sprintf("The limit (%s) is higher than the configured maximum of %s", upper.String(), b.max.String()))
+				}
+			}
+		}
+
+		return
+	}
+}
+
+// podResourcesFitNode checks that the total resources of the pod fits on a node of the configured size
+// A pod with requests larger than the node can never be scheduled.
+func podResourcesFitNode(nodeCPU, nodeMemory resource.Quantity) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		if nodeCPU.IsZero() && nodeMemory.IsZero() {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because no node size is configured", "")
+			return
+		}
+
+		spec := ps.GetPodTemplateSpec().Spec
+		requests := internal.PodResources(spec, internal.Requests)
+		limits := internal.PodResources(spec, internal.Limits)
+
+		score.Grade = scorecard.GradeAllOK
+
+		for _, r := range []struct {
+			name corev1.ResourceName
+			node resource.Quantity
+		}{
+			{corev1.ResourceCPU, nodeCPU},
+			{corev1.ResourceMemory, nodeMemory},
+		} {
+			if r.node.IsZero() {
+				continue
+			}
+			if request, ok := requests[r.name]; ok && request.Cmp(r.node) > 0 {
+				score.LowerGrade(scorecard.GradeCritical)
+				score.AddComment("", fmt.Sprintf("The pod requests more %s than a node has", r.name),
+					fmt.Sprintf("The pod requests %s in total, but a node only has %s. The pod can never be scheduled.", request.String(), r.node.String()))
+			} else if limit, ok := limits[r.name]; ok && limit.Cmp(r.node) > 0 {
+				score.LowerGrade(scorecard.GradeWarning)
+				score.AddComment("", fmt.Sprintf("The pod has a higher %s limit than a node has", r.name),
+					fmt.Sprintf("The pod has a limit of %s in total, but a node only has %s. The limit can never be reached.", limit.String(), r.node.String()))
+			}
+		}
+
+		return
+	}
+}
@@ -1,14 +1,42 @@
 package cronjob
 
 import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // Validate time zones without depending on the time zone database of the host
+
+	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/scorecard"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+const (
+	defaultMinScheduleInterval = 5 * time.Minute
+	maxJobsHistoryLimit        = 10
 )
 
-func Register(allChecks *checks.Checks) {
+func Register(allChecks *checks.Checks, kubernetesVersion config.Semver, policy config.CronJobPolicy) {
 	allChecks.RegisterCronJobCheck("CronJob has deadline", `Makes sure that all CronJobs has a configured deadline`, cronJobHasDeadline)
 	allChecks.RegisterCronJobCheck("CronJob RestartPolicy", `Makes sure CronJobs have a valid RestartPolicy`, cronJobHasRestartPolicy)
+	allChecks.RegisterOptionalCronJobCheck("CronJob BackoffLimit", `Makes sure that the backoffLimit of CronJobs is set, and not too high`, forCronJob(jobBackoffLimit))
+	allChecks.RegisterOptionalCronJobCheck("CronJob ActiveDeadlineSeconds", `Makes sure that CronJobs have an activeDeadlineSeconds, to stop jobs that hang`, forCronJob(jobActiveDeadlineSeconds))
+	allChecks.RegisterOptionalCronJobCheck("CronJob TTLSecondsAfterFinished", `Makes sure that finished jobs of CronJobs are deleted with ttlSecondsAfterFinished, and not only by the history limits`, forCronJob(jobTTLSecondsAfterFinished))
+	allChecks.RegisterOptionalCronJobCheck("CronJob ConcurrencyPolicy", `Makes sure that CronJobs have a concurrencyPolicy that prevents overlapping jobs`, cronJobConcurrencyPolicy)
+	allChecks.RegisterOptionalCronJobCheck("CronJob History Limits", `Makes sure that CronJobs keep failed jobs for debugging, and don't keep too many finished jobs`, cronJobHistoryLimits)
+	allChecks.RegisterCronJobCheck("CronJob Schedule", `Makes sure that the schedule of CronJobs is valid, and that it does not run more often than the interval set with --min-cronjob-interval`, cronJobSchedule(policy.MinScheduleInterval))
+	allChecks.RegisterCronJobCheck("CronJob TimeZone", `Makes sure that the timeZone of CronJobs is valid and supported by the Kubernetes version set with --kubernetes-version`, cronJobTimeZone(kubernetesVersion))
+
+	allChecks.RegisterOptionalJobCheck("Job BackoffLimit", `Makes sure that the backoffLimit of Jobs is set, and not too high`, forJob(jobBackoffLimit))
+	allChecks.RegisterOptionalJobCheck("Job ActiveDeadlineSeconds", `Makes sure that Jobs have an activeDeadlineSeconds, to stop jobs that hang`, forJob(jobActiveDeadlineSeconds))
+	allChecks.RegisterOptionalJobCheck("Job TTLSecondsAfterFinished", `Makes sure that finished Jobs are deleted with ttlSecondsAfterFinished`, forJob(jobTTLSecondsAfterFinished))
+}
+
+func forCronJob(fn func(batchv1.JobSpec) (scorecard.TestScore, error)) func(ks.CronJob) (scorecard.TestScore, error) {
+	return func(cjob ks.CronJob) (scorecard.TestScore, error) {
+		return fn(cjob.Spec().JobTemplate.Spec)
+	}
 }
 
 func cronJobHasDeadline(job ks.CronJob) (score scorecard.TestScore, err error) {
@@ -44,3 +72,108 @@ func cronJobHasRestartPolicy(job ks.CronJob) (score scorecard.TestScore, err err
 
 	return
 }
+
+// cronJobConcurrencyPolicy checks that jobs of the CronJob can not overlap
+func cronJobConcurrencyPolicy(cjob ks.CronJob) (score scorecard.TestScore, err error) {
+	policy := cjob.Spec().ConcurrencyPolicy
+	if policy == "" || policy == batchv1.AllowConcurrent {
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", "The CronJob allows concurrent jobs",
+			"A new job is started even if the previous job is still running, and slow jobs can pile up. "+
+				"Set concurrencyPolicy to Forbid to skip the new job, or Replace to stop the running job.")
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+	return
+}
+
+// cronJobHistoryLimits checks that failed jobs are kept for debugging, and that not too many jobs are kept
+func cronJobHistoryLimits(cjob ks.CronJob) (score scorecard.TestScore, err error) {
+	spec := cjob.Spec()
+	score.Grade = scorecard.GradeAllOK
+
+	if spec.FailedJobsHistoryLimit != nil && *spec.FailedJobsHistoryLimit == 0 {
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", "failedJobsHistoryLimit is 0",
+			"Failed jobs are deleted immediately, together with their pods and logs, which makes failures hard to debug.")
+	}
+
+	for _, limit := range []struct {
+		name  string
+		value *int32
+	}{
+		{"successfulJobsHistoryLimit", spec.SuccessfulJobsHistoryLimit},
+		{"failedJobsHistoryLimit", spec.FailedJobsHistoryLimit},
+	} {
+		if limit.value != nil && *limit.value > maxJobsHistoryLimit {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("", fmt.Sprintf("%s is %d", limit.name, *limit.value),
+				fmt.Sprintf("All finished jobs and their pods are kept in the cluster. Keep at most %d jobs, and store the results outside of the cluster if they are needed for longer.", maxJobsHistoryLimit))
+		}
+	}
+
+	return
+}
+
+// cronJobSchedule checks that the schedule is valid, and that it does not run too often
+func cronJobSchedule(minInterval time.Duration) func(ks.CronJob) (scorecard.TestScore, error) {
+	if minInterval == 0 {
+		minInterval = defaultMinScheduleInterval
+	}
+
+	return func(cjob ks.CronJob) (score scorecard.TestScore, err error) {
+		s, parseErr := parseSchedule(cjob.Spec().Schedule)
+		if parseErr != nil {
+			score.Grade = scorecard.GradeCritical
+			score.AddComment("", "The schedule is invalid", fmt.Sprintf("The CronJob will not be accepted: %s", parseErr))
+			return
+		}
+
+		if interval := s.minInterval(); interval < minInterval {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("", fmt.Sprintf("The CronJob runs every %s", interval),
+				fmt.Sprintf("Schedules that run more often than every %s create a lot of pods, use a Deployment with a loop instead.", minInterval))
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+}
+
+// cronJobTimeZone checks that the timeZone field is valid and supported by the Kubernetes version
+// Time zones set with CRON_TZ or TZ in the schedule are not officially supported by Kubernetes.
+func cronJobTimeZone(kubernetesVersion config.Semver) func(ks.CronJob) (scorecard.TestScore, error) {
+	// The CronJobTimeZone feature gate is enabled by default since 1.25, and GA since 1.27
+	timeZoneSupported := !kubernetesVersion.LessThan(config.Semver{Major: 1, Minor: 25})
+
+	return func(cjob ks.CronJob) (score scorecard.TestScore, err error) {
+		spec := cjob.Spec()
+		score.Grade = scorecard.GradeAllOK
+
+		if spec.TimeZone != nil {
+			if !timeZoneSupported {
+				// --kubernetes-version defaults to an old version, so this is only a warning
+				score.Grade = scorecard.GradeWarning
+				score.AddComment("", "timeZone is not supported by the Kubernetes version",
+					fmt.Sprintf("timeZone is supported since Kubernetes v1.25, and the target version is v%d.%d. The schedule is interpreted in the time zone of the kube-controller-manager.", kubernetesVersion.Major, kubernetesVersion.Minor))
+			} else if _, tzErr := time.LoadLocation(*spec.TimeZone); tzErr != nil || *spec.TimeZone == "" || *spec.TimeZone == "Local" {
+				score.Grade = scorecard.GradeCritical
+				score.AddComment("", fmt.Sprintf("Unknown timeZone %q", *spec.TimeZone),
+					"The timeZone must be a name from the tz database, such as Europe/Stockholm or Etc/UTC")
+			}
+		}
+
+		if s, parseErr := parseSchedule(spec.Schedule); parseErr == nil && s.timeZone != "" {
+			score.LowerGrade(scorecard.GradeWarning)
+			description := "Time zones in the schedule are not officially supported by Kubernetes."
+			if timeZoneSupported {
+				description += " Set the time zone with the timeZone field instead."
+			}
+			score.AddComment("", "The schedule sets the time zone with CRON_TZ or TZ", description)
+		}
+
+		return
+	}
+}
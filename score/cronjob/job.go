@@ -0,0 +1,65 @@
+package cronjob
+
+import (
+	"fmt"
+
+	"github.com/zegl/kube-score/scorecard"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+const (
+	defaultBackoffLimit = 6
+	maxBackoffLimit     = 10
+)
+
+// jobBackoffLimit checks that failing jobs are not retried too many times
+func jobBackoffLimit(spec batchv1.JobSpec) (score scorecard.TestScore, err error) {
+	if spec.BackoffLimit == nil {
+		score.Grade = scorecard.GradeAlmostOK
+		score.AddComment("", "backoffLimit is not set",
+			fmt.Sprintf("The Job is retried up to %d times before it's marked as failed. Set backoffLimit to a value that fits the job.", defaultBackoffLimit))
+		return
+	}
+
+	if *spec.BackoffLimit > maxBackoffLimit {
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", fmt.Sprintf("backoffLimit is %d", *spec.BackoffLimit),
+			fmt.Sprintf("A failing Job is retried with an exponential backoff of up to 6 minutes, and with a backoffLimit larger than %d it can take hours before it's marked as failed.", maxBackoffLimit))
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+	return
+}
+
+// jobActiveDeadlineSeconds checks that jobs can not run forever
+func jobActiveDeadlineSeconds(spec batchv1.JobSpec) (score scorecard.TestScore, err error) {
+	if spec.ActiveDeadlineSeconds == nil {
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", "activeDeadlineSeconds is not set",
+			"A Job that hangs runs until it's manually deleted. Set activeDeadlineSeconds to terminate the Job if it runs for longer than expected.")
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+	return
+}
+
+// jobTTLSecondsAfterFinished checks that finished jobs are cleaned up automatically
+func jobTTLSecondsAfterFinished(spec batchv1.JobSpec) (score scorecard.TestScore, err error) {
+	if spec.TTLSecondsAfterFinished == nil {
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", "ttlSecondsAfterFinished is not set",
+			"The Job and its pods are kept after the Job has finished, until they are manually deleted. Set ttlSecondsAfterFinished to delete them automatically.")
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+	return
+}
+
+func forJob(fn func(batchv1.JobSpec) (scorecard.TestScore, error)) func(batchv1.Job) (scorecard.TestScore, error) {
+	return func(job batchv1.Job) (scorecard.TestScore, error) {
+		return fn(job.Spec)
+	}
+}
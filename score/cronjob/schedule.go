@@ -0,0 +1,211 @@
+package cronjob
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schedule is a parsed cron schedule, in the format accepted by the CronJob controller
+type schedule struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek []bool
+
+	// every is set if the schedule uses the "@every <duration>" descriptor
+	every time.Duration
+
+	// timeZone is set if the schedule is prefixed with CRON_TZ= or TZ=
+	timeZone string
+
+	// daysRestricted is true if the day of month or day of week fields are not "*"
+	daysRestricted bool
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField     = field{name: "minute", min: 0, max: 59}
+	hourField       = field{name: "hour", min: 0, max: 23}
+	dayOfMonthField = field{name: "day of month", min: 1, max: 31}
+	monthField      = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dayOfWeekField = field{name: "day of week", min: 0, max: 6, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseSchedule parses a standard 5 field cron expression, or one of the descriptors such as "@daily" or "@every 5m"
+func parseSchedule(spec string) (*schedule, error) {
+	s := &schedule{}
+	spec = strings.TrimSpace(spec)
+
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		tz, rest, _ := strings.Cut(spec, " ")
+		_, s.timeZone, _ = strings.Cut(tz, "=")
+		spec = strings.TrimSpace(rest)
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration in %q: %w", spec, err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("the duration in %q must be at least one second", spec)
+		}
+		s.every = d
+		return s, nil
+	}
+
+	if expanded, ok := descriptors[spec]; ok {
+		spec = expanded
+	} else if strings.HasPrefix(spec, "@") {
+		return nil, fmt.Errorf("unknown descriptor %q", spec)
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, found %d in %q", len(fields), spec)
+	}
+
+	var err error
+	for i, f := range []struct {
+		field field
+		dst   *[]bool
+	}{
+		{minuteField, &s.minutes},
+		{hourField, &s.hours},
+		{dayOfMonthField, &s.daysOfMonth},
+		{monthField, &s.months},
+		{dayOfWeekField, &s.daysOfWeek},
+	} {
+		if *f.dst, err = parseField(fields[i], f.field); err != nil {
+			return nil, err
+		}
+	}
+
+	s.daysRestricted = !isWildcard(fields[2]) || !isWildcard(fields[4])
+
+	return s, nil
+}
+
+func isWildcard(expr string) bool {
+	return expr == "*" || expr == "?"
+}
+
+// parseField parses a comma separated list of values, ranges and steps, such as "1,5-10,*/15"
+func parseField(expr string, f field) ([]bool, error) {
+	res := make([]bool, f.max+1)
+
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+
+		var low, high int
+		switch {
+		case isWildcard(rangeExpr):
+			low, high = f.min, f.max
+		case strings.Contains(rangeExpr, "-"):
+			lowExpr, highExpr, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if low, err = parseValue(lowExpr, f); err != nil {
+				return nil, err
+			}
+			if high, err = parseValue(highExpr, f); err != nil {
+				return nil, err
+			}
+		default:
+			var err error
+			if low, err = parseValue(rangeExpr, f); err != nil {
+				return nil, err
+			}
+			high = low
+			// "5/10" means every 10th value starting at 5
+			if hasStep {
+				high = f.max
+			}
+		}
+
+		if low > high {
+			return nil, fmt.Errorf("invalid range %q in the %s field", rangeExpr, f.name)
+		}
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepExpr)
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q in the %s field", stepExpr, f.name)
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			res[v] = true
+		}
+	}
+
+	return res, nil
+}
+
+func parseValue(expr string, f field) (int, error) {
+	if v, ok := f.names[strings.ToLower(expr)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in the %s field", expr, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d is out of range (%d-%d) in the %s field", v, f.min, f.max, f.name)
+	}
+	return v, nil
+}
+
+// minInterval returns the shortest time between two runs of the schedule
+// Runs on different days are only considered if the schedule runs every day, the result is otherwise at least the
+// shortest time between two runs on the same day.
+func (s *schedule) minInterval() time.Duration {
+	if s.every > 0 {
+		return s.every
+	}
+
+	var times []int
+	for h, hOk := range s.hours {
+		for m, mOk := range s.minutes {
+			if hOk && mOk {
+				times = append(times, h*60+m)
+			}
+		}
+	}
+	sort.Ints(times)
+
+	const minutesPerDay = 24 * 60
+	shortest := minutesPerDay
+	for i := 1; i < len(times); i++ {
+		if gap := times[i] - times[i-1]; gap < shortest {
+			shortest = gap
+		}
+	}
+	if !s.daysRestricted && len(times) > 1 {
+		if gap := times[0] + minutesPerDay - times[len(times)-1]; gap < shortest {
+			shortest = gap
+		}
+	}
+
+	return time.Duration(shortest) * time.Minute
+}
@@ -0,0 +1,71 @@
+package cronjob
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScheduleMinInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		schedule string
+		expected time.Duration
+	}{
+		{"* * * * *", time.Minute},
+		{"*/5 * * * *", 5 * time.Minute},
+		{"0 * * * *", time.Hour},
+		{"0,30 9-17 * * mon-fri", 30 * time.Minute},
+		{"15 3 * * *", 24 * time.Hour},
+		{"0 22,2 * * *", 4 * time.Hour},
+		{"0 0,23 * * 1", 23 * time.Hour},
+		{"5/20 * * * *", 20 * time.Minute},
+		{"@hourly", time.Hour},
+		{"@daily", 24 * time.Hour},
+		{"@every 90s", 90 * time.Second},
+		{"CRON_TZ=Europe/Stockholm 0 * * * *", time.Hour},
+		{"0 0 1 JAN ?", 24 * time.Hour},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.schedule, func(t *testing.T) {
+			s, err := parseSchedule(tc.schedule)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, s.minInterval())
+		})
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, schedule := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"@reboot",
+		"@every 5 minutes",
+		"foo * * * *",
+	} {
+		t.Run(schedule, func(t *testing.T) {
+			_, err := parseSchedule(schedule)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseScheduleTimeZone(t *testing.T) {
+	t.Parallel()
+	s, err := parseSchedule("TZ=UTC 0 0 * * *")
+	assert.NoError(t, err)
+	assert.Equal(t, "UTC", s.timeZone)
+}
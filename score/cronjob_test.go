@@ -2,7 +2,12 @@ package score
 
 import (
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -65,3 +70,120 @@ func TestCronJobHasRestartPolicyValid(t *testing.T) {
 		})
 	}
 }
+
+var cronJobRunConfig = &config.RunConfiguration{
+	KubernetesVersion: config.Semver{Major: 1, Minor: 29},
+	EnabledOptionalTests: map[string]struct{}{
+		"cronjob-backofflimit":          {},
+		"cronjob-activedeadlineseconds": {},
+		"cronjob-concurrencypolicy":     {},
+		"cronjob-history-limits":        {},
+	},
+}
+
+var jobRunConfig = &config.RunConfiguration{
+	EnabledOptionalTests: map[string]struct{}{
+		"job-backofflimit":            {},
+		"job-activedeadlineseconds":   {},
+		"job-ttlsecondsafterfinished": {},
+	},
+}
+
+func TestCronJobRobust(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []string{"batchv1beta1", "batchv1"} {
+		for _, check := range []string{
+			"CronJob BackoffLimit",
+			"CronJob ActiveDeadlineSeconds",
+			"CronJob ConcurrencyPolicy",
+			"CronJob History Limits",
+			"CronJob Schedule",
+			"CronJob TimeZone",
+		} {
+			t.Run(v+"/"+check, func(t *testing.T) {
+				testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("cronjob-" + v + "-robust.yaml")}, nil, cronJobRunConfig, check, scorecard.GradeAllOK)
+			})
+		}
+	}
+}
+
+func TestCronJobFragile(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []string{"batchv1beta1", "batchv1"} {
+		t.Run(v, func(t *testing.T) {
+			files := func() []ks.NamedReader { return []ks.NamedReader{testFile("cronjob-" + v + "-fragile.yaml")} }
+
+			assert.Equal(t, []string{"backoffLimit is 20"}, getSummaries(t, files(), nil, cronJobRunConfig, "CronJob BackoffLimit"))
+			assert.Equal(t, []string{"activeDeadlineSeconds is not set"}, getSummaries(t, files(), nil, cronJobRunConfig, "CronJob ActiveDeadlineSeconds"))
+			assert.Equal(t, []string{"The CronJob allows concurrent jobs"}, getSummaries(t, files(), nil, cronJobRunConfig, "CronJob ConcurrencyPolicy"))
+			assert.Equal(t, []string{
+				"failedJobsHistoryLimit is 0",
+				"successfulJobsHistoryLimit is 50",
+			}, getSummaries(t, files(), nil, cronJobRunConfig, "CronJob History Limits"))
+			assert.Equal(t, []string{"The CronJob runs every 1m0s"}, getSummaries(t, files(), nil, cronJobRunConfig, "CronJob Schedule"))
+
+			comments := testExpectedScoreWithConfig(t, files(), nil, cronJobRunConfig, "CronJob TimeZone", scorecard.GradeCritical)
+			assert.Equal(t, []scorecard.TestScoreComment{
+				{
+					Summary:     `Unknown timeZone "Mars/Olympus_Mons"`,
+					Description: "The timeZone must be a name from the tz database, such as Europe/Stockholm or Etc/UTC",
+				},
+				{
+					Summary:     "The schedule sets the time zone with CRON_TZ or TZ",
+					Description: "Time zones in the schedule are not officially supported by Kubernetes. Set the time zone with the timeZone field instead.",
+				},
+			}, comments)
+		})
+	}
+}
+
+func TestCronJobScheduleInvalid(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "cronjob-batchv1-schedule-invalid.yaml", "CronJob Schedule", scorecard.GradeCritical)
+	assert.Equal(t, []scorecard.TestScoreComment{{
+		Summary:     "The schedule is invalid",
+		Description: "The CronJob will not be accepted: value 25 is out of range (0-23) in the hour field",
+	}}, comments)
+}
+
+func TestCronJobScheduleMinInterval(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("cronjob-batchv1-fragile.yaml")}, nil, &config.RunConfiguration{
+		CronJobPolicy: config.CronJobPolicy{MinScheduleInterval: time.Minute},
+	}, "CronJob Schedule", scorecard.GradeAllOK)
+}
+
+func TestCronJobTimeZoneOldKubernetesVersion(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("cronjob-batchv1-robust.yaml")}, nil, &config.RunConfiguration{
+		KubernetesVersion: config.Semver{Major: 1, Minor: 24},
+	}, "CronJob TimeZone", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "timeZone is not supported by the Kubernetes version", comments[0].Summary)
+}
+
+func TestCronJobTTLSecondsAfterFinished(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("cronjob-batchv1-fragile.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"cronjob-ttlsecondsafterfinished": {}},
+	}, "CronJob TTLSecondsAfterFinished", scorecard.GradeWarning)
+}
+
+func TestJobRobust(t *testing.T) {
+	t.Parallel()
+	for _, check := range []string{"Job BackoffLimit", "Job ActiveDeadlineSeconds", "Job TTLSecondsAfterFinished"} {
+		t.Run(check, func(t *testing.T) {
+			testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("job-batchv1-robust.yaml")}, nil, jobRunConfig, check, scorecard.GradeAllOK)
+		})
+	}
+}
+
+func TestJobMissingDeadlineAndTTL(t *testing.T) {
+	t.Parallel()
+	files := func() []ks.NamedReader { return []ks.NamedReader{testFile("job-batchv1.yaml")} }
+	testExpectedScoreWithConfig(t, files(), nil, jobRunConfig, "Job BackoffLimit", scorecard.GradeAllOK)
+	testExpectedScoreWithConfig(t, files(), nil, jobRunConfig, "Job ActiveDeadlineSeconds", scorecard.GradeWarning)
+	testExpectedScoreWithConfig(t, files(), nil, jobRunConfig, "Job TTLSecondsAfterFinished", scorecard.GradeWarning)
+}
@@ -2,6 +2,7 @@ package disruptionbudget
 
 import (
 	"fmt"
+	"strings"
 
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
@@ -10,12 +11,17 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 )
 
-func Register(allChecks *checks.Checks, budgets ks.PodDisruptionBudgets) {
+func Register(allChecks *checks.Checks, all ks.AllTypes) {
+	budgets := ks.PodDisruptionBudgets(all)
+
 	allChecks.RegisterStatefulSetCheck("StatefulSet has PodDisruptionBudget", `Makes sure that all StatefulSets are targeted by a PDB`, statefulSetHas(budgets.PodDisruptionBudgets()))
 	allChecks.RegisterDeploymentCheck("Deployment has PodDisruptionBudget", `Makes sure that all Deployments are targeted by a PDB`, deploymentHas(budgets.PodDisruptionBudgets()))
 	allChecks.RegisterPodDisruptionBudgetCheck("PodDisruptionBudget has policy", `Makes sure that PodDisruptionBudgets specify minAvailable or maxUnavailable`, hasPolicy)
+	allChecks.RegisterPodDisruptionBudgetCheck("PodDisruptionBudget Feasibility", `Makes sure that PodDisruptionBudgets allow at least one pod to be evicted, but not all pods, based on the replicas of the targeted Deployments and StatefulSets and the minReplicas of their HorizontalPodAutoscalers`, feasible(all))
 }
 
 func hasMatching(budgets []ks.PodDisruptionBudget, namespace string, labels map[string]string) (bool, string, error) {
@@ -108,3 +114,135 @@ func hasPolicy(pdb ks.PodDisruptionBudget) (score scorecard.TestScore, err error
 
 	return
 }
+
+type pdbTarget struct {
+	kind, name string
+	replicas   int32
+	hasHPA     bool
+}
+
+// targetsOf returns the Deployments and StatefulSets in the same namespace as the budget, that have pods matched by the selector
+// The replicas of a workload targeted by a HorizontalPodAutoscaler is the minReplicas of the HPA.
+func targetsOf(pdb ks.PodDisruptionBudget, all ks.AllTypes) ([]pdbTarget, error) {
+	selector, err := metav1.LabelSelectorAsSelector(pdb.PodDisruptionBudgetSelector())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create selector: %w", err)
+	}
+
+	var targets []pdbTarget
+	addTarget := func(meta metav1.TypeMeta, objectMeta metav1.ObjectMeta, templateLabels map[string]string, replicas *int32) {
+		if objectMeta.Namespace != pdb.Namespace() || !selector.Matches(internal.MapLabels(templateLabels)) {
+			return
+		}
+		t := pdbTarget{kind: meta.Kind, name: objectMeta.Name, replicas: ptr.Deref(replicas, 1)}
+		for _, hpa := range all.HorizontalPodAutoscalers() {
+			target := hpa.HpaTarget()
+			if hpa.GetObjectMeta().Namespace == objectMeta.Namespace && strings.EqualFold(target.Kind, meta.Kind) && target.Name == objectMeta.Name {
+				t.replicas = ptr.Deref(hpa.MinReplicas(), 1)
+				t.hasHPA = true
+				break
+			}
+		}
+		targets = append(targets, t)
+	}
+
+	for _, d := range all.Deployments() {
+		deployment := d.Deployment()
+		addTarget(deployment.TypeMeta, deployment.ObjectMeta, deployment.Spec.Template.Labels, deployment.Spec.Replicas)
+	}
+	for _, s := range all.StatefulSets() {
+		statefulset := s.StatefulSet()
+		addTarget(statefulset.TypeMeta, statefulset.ObjectMeta, statefulset.Spec.Template.Labels, statefulset.Spec.Replicas)
+	}
+
+	return targets, nil
+}
+
+// feasible checks that the budget allows at least one pod to be evicted, but not all of them
+// The allowed disruptions are calculated in the same way as by the disruption controller, with the replicas of all
+// matching Deployments and StatefulSets as the expected number of pods.
+func feasible(all ks.AllTypes) func(ks.PodDisruptionBudget) (scorecard.TestScore, error) {
+	return func(pdb ks.PodDisruptionBudget) (score scorecard.TestScore, err error) {
+		spec := pdb.Spec()
+		if spec.MinAvailable == nil && spec.MaxUnavailable == nil {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because the PodDisruptionBudget has no policy", "")
+			return
+		}
+
+		// An invalid value is reported on the budget, instead of failing the whole run
+		for _, v := range []struct {
+			field string
+			value *intstr.IntOrString
+		}{
+			{"maxUnavailable", spec.MaxUnavailable},
+			{"minAvailable", spec.MinAvailable},
+		} {
+			if v.value == nil {
+				continue
+			}
+			if _, scaleErr := intstr.GetScaledValueFromIntOrPercent(v.value, 1, true); scaleErr != nil {
+				score.Grade = scorecard.GradeCritical
+				score.AddComment("", fmt.Sprintf("The PodDisruptionBudget has an invalid %s", v.field),
+					fmt.Sprintf("invalid %s: %s. The value must be a number or a percentage, such as 1 or 25%%.", v.field, scaleErr))
+			}
+		}
+		if score.Grade == scorecard.GradeCritical {
+			return
+		}
+
+		targets, err := targetsOf(pdb, all)
+		if err != nil {
+			return score, err
+		}
+		if len(targets) == 0 {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because the PodDisruptionBudget does not match any Deployment or StatefulSet", "")
+			return
+		}
+
+		var expectedPods int32
+		var names []string
+		for _, t := range targets {
+			expectedPods += t.replicas
+			name := fmt.Sprintf("%s %s (%d replicas)", t.kind, t.name, t.replicas)
+			if t.hasHPA {
+				name = fmt.Sprintf("%s %s (%d minReplicas)", t.kind, t.name, t.replicas)
+			}
+			names = append(names, name)
+		}
+		targetDescription := strings.Join(names, ", ")
+
+		var allowed int32
+		var policy string
+		if spec.MaxUnavailable != nil {
+			maxUnavailable, _ := intstr.GetScaledValueFromIntOrPercent(spec.MaxUnavailable, int(expectedPods), true)
+			allowed = int32(maxUnavailable)
+			policy = "maxUnavailable " + spec.MaxUnavailable.String()
+		} else {
+			minAvailable, _ := intstr.GetScaledValueFromIntOrPercent(spec.MinAvailable, int(expectedPods), true)
+			allowed = expectedPods - int32(minAvailable)
+			policy = "minAvailable " + spec.MinAvailable.String()
+		}
+
+		switch {
+		case allowed <= 0:
+			score.Grade = scorecard.GradeCritical
+			score.AddCommentWithURL("", "The PodDisruptionBudget does not allow any pod to be evicted",
+				fmt.Sprintf("With %s and %d expected pods from %s, no pods can be evicted. This blocks node drains and cluster upgrades. "+
+					"Lower the budget, or increase the number of replicas.", policy, expectedPods, targetDescription),
+				"https://kubernetes.io/docs/tasks/run-application/configure-pdb/#think-about-how-your-application-reacts-to-disruptions")
+		case allowed >= expectedPods:
+			score.Grade = scorecard.GradeWarning
+			score.AddCommentWithURL("", "The PodDisruptionBudget allows all pods to be evicted",
+				fmt.Sprintf("With %s and %d expected pods from %s, all pods can be evicted at the same time, and the budget has no effect.", policy, expectedPods, targetDescription),
+				"https://kubernetes.io/docs/tasks/run-application/configure-pdb/#think-about-how-your-application-reacts-to-disruptions")
+		default:
+			score.Grade = scorecard.GradeAllOK
+		}
+
+		return
+	}
+}
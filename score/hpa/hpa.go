@@ -1,15 +1,20 @@
 package hpa
 
 import (
+	"github.com/zegl/kube-score/config"
 	"github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/scorecard"
 	"k8s.io/utils/ptr"
 )
 
-func Register(allChecks *checks.Checks, allTargetableObjs []domain.BothMeta) {
-	allChecks.RegisterHorizontalPodAutoscalerCheck("HorizontalPodAutoscaler has target", `Makes sure that the HPA targets a valid object`, hpaHasTarget(allTargetableObjs))
+func Register(allChecks *checks.Checks, all domain.AllTypes, policy config.AutoscalingPolicy) {
+	allChecks.RegisterHorizontalPodAutoscalerCheck("HorizontalPodAutoscaler has target", `Makes sure that the HPA targets a valid object`, hpaHasTarget(all.Metas()))
 	allChecks.RegisterHorizontalPodAutoscalerCheck("HorizontalPodAutoscaler Replicas", `Makes sure that the HPA has multiple replicas`, hpaHasMultipleReplicas())
+	allChecks.RegisterHorizontalPodAutoscalerCheck("HorizontalPodAutoscaler Target Resource Requests", `Makes sure that the containers of the target have requests for the resources that the HPA scales on by utilization`, hpaTargetHasResourceRequests(all.PodSpeccers()))
+	allChecks.RegisterHorizontalPodAutoscalerCheck("HorizontalPodAutoscaler Replica Bounds", `Makes sure that maxReplicas is higher than minReplicas, and not higher than the maximum set with --max-hpa-replicas`, hpaReplicaBounds(policy))
+	allChecks.RegisterHorizontalPodAutoscalerCheck("HorizontalPodAutoscaler ScaleDown Stabilization", `Makes sure that the HPA has a scale down stabilization window`, hpaScaleDownStabilization)
+	allChecks.RegisterHorizontalPodAutoscalerCheck("HorizontalPodAutoscaler VerticalPodAutoscaler Conflict", `Makes sure that the target of the HPA is not updated by a VerticalPodAutoscaler on the same resources as the HPA scales on`, hpaVPAConflict(all.VerticalPodAutoscalers()))
 }
 
 func hpaHasTarget(allTargetableObjs []domain.BothMeta) func(hpa domain.HpaTargeter) (scorecard.TestScore, error) {
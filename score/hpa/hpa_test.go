@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/zegl/kube-score/domain"
@@ -185,6 +186,18 @@ func (d hpav1) HpaTarget() v1.CrossVersionObjectReference {
 	return d.Spec.ScaleTargetRef
 }
 
+func (d hpav1) MaxReplicas() int32 {
+	return d.Spec.MaxReplicas
+}
+
+func (d hpav1) Metrics() []autoscalingv2.MetricSpec {
+	return nil
+}
+
+func (d hpav1) Behavior() *autoscalingv2.HorizontalPodAutoscalerBehavior {
+	return nil
+}
+
 func (d hpav1) FileLocation() domain.FileLocation {
 	return domain.FileLocation{}
 }
@@ -0,0 +1,218 @@
+package hpa
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zegl/kube-score/config"
+	"github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const defaultMaxReplicas = 100
+
+// The update modes where the VerticalPodAutoscaler does not change the resources of running pods
+var passiveVPAUpdateModes = map[string]struct{}{
+	"Off":     {},
+	"Initial": {},
+}
+
+type resourceMetric struct {
+	name      corev1.ResourceName
+	container string
+}
+
+// resourceMetrics returns the resource and container resource metrics of the HPA
+// If onlyUtilization is set, only the metrics with a target type of Utilization are returned. An HPA without any
+// metrics uses average CPU utilization.
+func resourceMetrics(hpa domain.HpaTargeter, onlyUtilization bool) []resourceMetric {
+	metrics := hpa.Metrics()
+	if len(metrics) == 0 {
+		return []resourceMetric{{name: corev1.ResourceCPU}}
+	}
+
+	var res []resourceMetric
+	for _, m := range metrics {
+		switch {
+		case m.Resource != nil:
+			if !onlyUtilization || m.Resource.Target.Type == autoscalingv2.UtilizationMetricType {
+				res = append(res, resourceMetric{name: m.Resource.Name})
+			}
+		case m.ContainerResource != nil:
+			if !onlyUtilization || m.ContainerResource.Target.Type == autoscalingv2.UtilizationMetricType {
+				res = append(res, resourceMetric{name: m.ContainerResource.Name, container: m.ContainerResource.Container})
+			}
+		}
+	}
+	return res
+}
+
+func findTarget(hpa domain.HpaTargeter, podSpeccers []domain.PodSpecer) (domain.PodSpecer, bool) {
+	targetRef := hpa.HpaTarget()
+	for _, ps := range podSpeccers {
+		if ps.GetTypeMeta().Kind == targetRef.Kind &&
+			ps.GetObjectMeta().Name == targetRef.Name &&
+			ps.GetObjectMeta().Namespace == hpa.GetObjectMeta().Namespace {
+			return ps, true
+		}
+	}
+	return nil, false
+}
+
+// hpaTargetHasResourceRequests checks that the containers of the target have requests for the resources that the
+// HPA scales on by utilization
+// The utilization is calculated as a percentage of the request, and the HPA fails to calculate it if any container
+// lacks a request.
+func hpaTargetHasResourceRequests(podSpeccers []domain.PodSpecer) func(domain.HpaTargeter) (scorecard.TestScore, error) {
+	return func(hpa domain.HpaTargeter) (score scorecard.TestScore, err error) {
+		metrics := resourceMetrics(hpa, true)
+		if len(metrics) == 0 {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because the HPA does not scale on resource utilization", "")
+			return
+		}
+
+		target, ok := findTarget(hpa, podSpeccers)
+		if !ok {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because the HPA target was not found", "")
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		containers := target.GetPodTemplateSpec().Spec.Containers
+		for _, m := range metrics {
+			if m.container != "" {
+				found := false
+				for _, c := range containers {
+					if c.Name == m.container {
+						found = true
+						break
+					}
+				}
+				if !found {
+					score.Grade = scorecard.GradeCritical
+					score.AddComment(m.container, "The HPA scales on a container that does not exist",
+						fmt.Sprintf("The HPA scales on the %s utilization of the container %s, but the container is not defined in the target", m.name, m.container))
+				}
+			}
+
+			for _, c := range containers {
+				if m.container != "" && c.Name != m.container {
+					continue
+				}
+				if request, hasRequest := c.Resources.Requests[m.name]; !hasRequest || request.IsZero() {
+					score.Grade = scorecard.GradeCritical
+					score.AddComment(c.Name, fmt.Sprintf("The container has no %s request", m.name),
+						fmt.Sprintf("The HPA scales on %s utilization, which is calculated as a percentage of the request. Without a request, the HPA can not calculate the utilization and will not scale.", m.name))
+				}
+			}
+		}
+
+		return
+	}
+}
+
+// hpaReplicaBounds checks that maxReplicas is larger than minReplicas, and not larger than the configured maximum
+func hpaReplicaBounds(policy config.AutoscalingPolicy) func(domain.HpaTargeter) (scorecard.TestScore, error) {
+	maxAllowed := policy.MaxReplicas
+	if maxAllowed == 0 {
+		maxAllowed = defaultMaxReplicas
+	}
+
+	return func(hpa domain.HpaTargeter) (score scorecard.TestScore, err error) {
+		minReplicas := ptr.Deref(hpa.MinReplicas(), 1)
+		maxReplicas := hpa.MaxReplicas()
+
+		switch {
+		case maxReplicas < minReplicas:
+			score.Grade = scorecard.GradeCritical
+			score.AddComment("", "maxReplicas is lower than minReplicas",
+				fmt.Sprintf("maxReplicas (%d) must be at least minReplicas (%d)", maxReplicas, minReplicas))
+		case maxReplicas == minReplicas:
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("", "maxReplicas is the same as minReplicas",
+				fmt.Sprintf("The HPA can never scale, as both minReplicas and maxReplicas is %d. Remove the HPA and set the replicas of the target, or increase maxReplicas.", maxReplicas))
+		case maxReplicas > maxAllowed:
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("", "maxReplicas is very high",
+				fmt.Sprintf("maxReplicas (%d) is higher than the configured maximum of %d. An unbounded HPA can exhaust the resources of the cluster, or the capacity of dependencies such as databases.", maxReplicas, maxAllowed))
+		default:
+			score.Grade = scorecard.GradeAllOK
+		}
+
+		return
+	}
+}
+
+// hpaScaleDownStabilization checks that the HPA has a scale down stabilization window, to prevent flapping
+func hpaScaleDownStabilization(hpa domain.HpaTargeter) (score scorecard.TestScore, err error) {
+	behavior := hpa.Behavior()
+	if behavior == nil || behavior.ScaleDown == nil {
+		score.Grade = scorecard.GradeAlmostOK
+		if strings.HasSuffix(hpa.GetTypeMeta().APIVersion, "/v1") || strings.HasSuffix(hpa.GetTypeMeta().APIVersion, "/v2beta1") {
+			score.AddComment("", "The HPA does not configure the scale down behavior",
+				fmt.Sprintf("%s does not support configuring the scaling behavior, and the default stabilization window of 300 seconds is used. Use autoscaling/v2 to configure behavior.scaleDown.", hpa.GetTypeMeta().APIVersion))
+		} else {
+			score.AddComment("", "The HPA does not configure the scale down behavior",
+				"The default stabilization window of 300 seconds is used. Set behavior.scaleDown.stabilizationWindowSeconds to make the behavior explicit.")
+		}
+		return
+	}
+
+	scaleDown := behavior.ScaleDown
+	if scaleDown.SelectPolicy != nil && *scaleDown.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		score.Grade = scorecard.GradeAllOK
+		return
+	}
+
+	if scaleDown.StabilizationWindowSeconds != nil && *scaleDown.StabilizationWindowSeconds == 0 {
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", "The HPA has no scale down stabilization window",
+			"With behavior.scaleDown.stabilizationWindowSeconds set to 0, the HPA scales down as soon as the load drops. Short spikes in load makes the replicas flap up and down.")
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+	return
+}
+
+// hpaVPAConflict checks that the target of the HPA is not also targeted by a VerticalPodAutoscaler that updates the
+// same resources that the HPA scales on
+func hpaVPAConflict(vpas []domain.VerticalPodAutoscaler) func(domain.HpaTargeter) (scorecard.TestScore, error) {
+	return func(hpa domain.HpaTargeter) (score scorecard.TestScore, err error) {
+		score.Grade = scorecard.GradeAllOK
+
+		targetRef := hpa.HpaTarget()
+		hpaResources := make(map[corev1.ResourceName]struct{})
+		for _, m := range resourceMetrics(hpa, false) {
+			hpaResources[m.name] = struct{}{}
+		}
+
+		for _, vpa := range vpas {
+			vpaTarget := vpa.VpaTarget()
+			if vpa.GetObjectMeta().Namespace != hpa.GetObjectMeta().Namespace ||
+				vpaTarget.Kind != targetRef.Kind || vpaTarget.Name != targetRef.Name {
+				continue
+			}
+			if _, passive := passiveVPAUpdateModes[vpa.UpdateMode()]; passive {
+				continue
+			}
+			for _, r := range vpa.ControlledResources() {
+				if _, ok := hpaResources[r]; ok {
+					score.Grade = scorecard.GradeCritical
+					score.AddComment("", fmt.Sprintf("The target is also scaled on %s by a VerticalPodAutoscaler", r),
+						fmt.Sprintf("The VerticalPodAutoscaler %s updates the %s requests of the pods, which changes the utilization that the HPA scales on. Set the update mode of the VPA to Off, or remove %s from its controlledResources.", vpa.GetObjectMeta().Name, r, r))
+				}
+			}
+		}
+
+		return
+	}
+}
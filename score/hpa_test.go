@@ -3,6 +3,10 @@ package score
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -30,3 +34,60 @@ func TestHorizontalPodAutoscalerMinReplicasNok(t *testing.T) {
 	t.Parallel()
 	testExpectedScore(t, "hpa-min-replicas-nok.yaml", "HorizontalPodAutoscaler Replicas", scorecard.GradeWarning)
 }
+
+func TestHorizontalPodAutoscalerTargetResourceRequestsMissing(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "hpa-autoscalingv2-resource-requests-missing.yaml", "HorizontalPodAutoscaler Target Resource Requests", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "app", comments[0].Path)
+	assert.Equal(t, "The container has no cpu request", comments[0].Summary)
+}
+
+func TestHorizontalPodAutoscalerV1TargetResourceRequestsOk(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "hpa-autoscalingv1-resource-requests-ok.yaml", "HorizontalPodAutoscaler Target Resource Requests", scorecard.GradeAllOK)
+}
+
+func TestHorizontalPodAutoscalerV2beta1ContainerResourceRequestsMissing(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "hpa-autoscalingv2beta1-container-resource-requests-missing.yaml", "HorizontalPodAutoscaler Target Resource Requests", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The container has no memory request", comments[0].Summary)
+}
+
+func TestHorizontalPodAutoscalerTargetResourceRequestsNoTarget(t *testing.T) {
+	t.Parallel()
+	assert.True(t, wasSkipped(t, []ks.NamedReader{testFile("hpa-has-no-target.yaml")}, nil, nil, "HorizontalPodAutoscaler Target Resource Requests"))
+}
+
+func TestHorizontalPodAutoscalerReplicaBounds(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "hpa-max-replicas-below-min.yaml", "HorizontalPodAutoscaler Replica Bounds", scorecard.GradeCritical)
+	testExpectedScore(t, "hpa-max-replicas-equal-min.yaml", "HorizontalPodAutoscaler Replica Bounds", scorecard.GradeWarning)
+	testExpectedScore(t, "hpa-max-replicas-high.yaml", "HorizontalPodAutoscaler Replica Bounds", scorecard.GradeWarning)
+	testExpectedScore(t, "hpa-min-replicas-ok.yaml", "HorizontalPodAutoscaler Replica Bounds", scorecard.GradeAllOK)
+}
+
+func TestHorizontalPodAutoscalerReplicaBoundsConfiguredMax(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("hpa-max-replicas-high.yaml")}, nil, &config.RunConfiguration{
+		AutoscalingPolicy: config.AutoscalingPolicy{MaxReplicas: 1000},
+	}, "HorizontalPodAutoscaler Replica Bounds", scorecard.GradeAllOK)
+}
+
+func TestHorizontalPodAutoscalerScaleDownStabilization(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "hpa-autoscalingv1-scale-down-default.yaml", "HorizontalPodAutoscaler ScaleDown Stabilization", scorecard.GradeAlmostOK)
+	testExpectedScore(t, "hpa-autoscalingv2beta2-scale-down-no-stabilization.yaml", "HorizontalPodAutoscaler ScaleDown Stabilization", scorecard.GradeWarning)
+	testExpectedScore(t, "hpa-autoscalingv2-scale-down-stabilization.yaml", "HorizontalPodAutoscaler ScaleDown Stabilization", scorecard.GradeAllOK)
+}
+
+func TestHorizontalPodAutoscalerVerticalPodAutoscalerConflict(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "hpa-vpa-conflict.yaml", "HorizontalPodAutoscaler VerticalPodAutoscaler Conflict", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The target is also scaled on cpu by a VerticalPodAutoscaler", comments[0].Summary)
+
+	testExpectedScore(t, "hpa-vpa-update-mode-off.yaml", "HorizontalPodAutoscaler VerticalPodAutoscaler Conflict", scorecard.GradeAllOK)
+	testExpectedScore(t, "hpa-vpa-memory-only.yaml", "HorizontalPodAutoscaler VerticalPodAutoscaler Conflict", scorecard.GradeAllOK)
+}
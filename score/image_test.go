@@ -0,0 +1,76 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestPodContainerTagRegistryWithPort(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "pod-image-tag-registry-port.yaml", "Container Image Tag", scorecard.GradeCritical)
+}
+
+func TestPodContainerImageDigest(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-image-references.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-image-digest": {}},
+	}, "Container Image Digest", scorecard.GradeWarning)
+
+	var paths []string
+	for _, c := range comments {
+		paths = append(paths, c.Path)
+	}
+	assert.Equal(t, []string{"init", "app", "debugger"}, paths)
+}
+
+func TestPodContainerImageRegistrySkippedWithoutPolicy(t *testing.T) {
+	t.Parallel()
+	skipped := wasSkipped(t, []ks.NamedReader{testFile("pod-image-references.yaml")}, nil, nil, "Container Image Registry")
+	assert.True(t, skipped)
+}
+
+func TestPodContainerImageRegistryAllowed(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-image-references.yaml")}, nil, &config.RunConfiguration{
+		ImagePolicy: config.ImagePolicy{
+			AllowedRegistries: []string{"gcr.io/org", "docker.io/library", "registry:5000"},
+		},
+	}, "Container Image Registry", scorecard.GradeCritical)
+	assert.Equal(t, []scorecard.TestScoreComment{{
+		Path:        "debugger",
+		Summary:     "Image quay.io/tools/debug:dev-123 is not from an allowed registry",
+		Description: "Images must be from one of: gcr.io/org, docker.io/library, registry:5000",
+	}}, comments)
+}
+
+func TestPodContainerImageRegistryDenied(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-image-references.yaml")}, nil, &config.RunConfiguration{
+		ImagePolicy: config.ImagePolicy{
+			DeniedRegistries: []string{"docker.io", "gcr.io/other"},
+		},
+	}, "Container Image Registry", scorecard.GradeCritical)
+	assert.Equal(t, []scorecard.TestScoreComment{{
+		Path:        "proxy",
+		Summary:     "Image nginx:1.25@sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac is from a denied registry",
+		Description: "Images from docker.io are not allowed",
+	}}, comments)
+}
+
+func TestPodContainerImageMutableTag(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-image-references.yaml")}, nil, &config.RunConfiguration{
+		ImagePolicy: config.ImagePolicy{
+			MutableTags: []string{"main", "dev-*"},
+		},
+	}, "Container Image Mutable Tag", scorecard.GradeCritical)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "app", comments[0].Path)
+	assert.Equal(t, "Image with mutable tag main", comments[0].Summary)
+	assert.Equal(t, "debugger", comments[1].Path)
+	assert.Equal(t, "Image with mutable tag dev-123", comments[1].Summary)
+}
@@ -0,0 +1,211 @@
+package ingress
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const ingressClassAnnotation = "kubernetes.io/ingress.class"
+
+// hostMatches returns true if the host is matched by the pattern, which can be a wildcard host such as *.example.com
+// A wildcard only matches a single DNS label.
+func hostMatches(pattern, host string) bool {
+	if pattern == host {
+		return true
+	}
+	if !strings.HasPrefix(pattern, "*.") {
+		return false
+	}
+	firstLabel, rest, found := strings.Cut(host, ".")
+	return found && firstLabel != "" && firstLabel != "*" && rest == pattern[2:]
+}
+
+// ingressTLS checks that all hosts of the TLS sections are used by a rule, and that all TLS sections have a secret
+func ingressTLS(ingress ks.Ingress) (score scorecard.TestScore, err error) {
+	tls := ingress.TLS()
+	if len(tls) == 0 {
+		score.Grade = scorecard.GradeAllOK
+		score.Skipped = true
+		score.AddComment("", "Skipped because the Ingress has no TLS configuration", "")
+		return
+	}
+
+	score.Grade = scorecard.GradeAllOK
+
+	for _, t := range tls {
+		if t.SecretName == "" {
+			score.LowerGrade(scorecard.GradeWarning)
+			score.AddComment(strings.Join(t.Hosts, ","), "The TLS configuration has no secretName",
+				"Without a secretName, the default certificate of the ingress controller is used, which is likely to not be valid for the hosts.")
+		}
+
+		for _, tlsHost := range t.Hosts {
+			used := false
+			for _, rule := range ingress.Rules() {
+				if rule.Host != "" && hostMatches(tlsHost, rule.Host) {
+					used = true
+					break
+				}
+			}
+			if !used {
+				score.LowerGrade(scorecard.GradeWarning)
+				score.AddComment(tlsHost, "The TLS host is not used by any rule",
+					"The host is listed in the TLS configuration, but no rule routes traffic for it. This is often caused by a typo in the TLS host or the rule host.")
+			}
+		}
+	}
+
+	return
+}
+
+// ingressHosts checks that the hosts of the rules are valid DNS names, and warns about wildcard hosts
+func ingressHosts(ingress ks.Ingress) (score scorecard.TestScore, err error) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, rule := range ingress.Rules() {
+		host := rule.Host
+		if host == "" {
+			continue
+		}
+
+		if net.ParseIP(host) != nil {
+			score.LowerGrade(scorecard.GradeCritical)
+			score.AddComment(host, "The host is an IP address", "The host of an Ingress rule must be a DNS name, IP addresses are not allowed.")
+			continue
+		}
+
+		if strings.Contains(host, "*") {
+			if errs := validation.IsWildcardDNS1123Subdomain(host); len(errs) > 0 {
+				score.LowerGrade(scorecard.GradeCritical)
+				score.AddComment(host, "The wildcard host is invalid", strings.Join(errs, ", "))
+				continue
+			}
+			score.LowerGrade(scorecard.GradeAlmostOK)
+			score.AddComment(host, "The host is a wildcard",
+				fmt.Sprintf("The rule matches all subdomains of %s. Make sure that this is intended, and that no other Ingress is supposed to serve one of the subdomains.", host[2:]))
+			continue
+		}
+
+		if errs := validation.IsDNS1123Subdomain(host); len(errs) > 0 {
+			score.LowerGrade(scorecard.GradeCritical)
+			score.AddComment(host, "The host is invalid", strings.Join(errs, ", "))
+		}
+	}
+
+	return
+}
+
+// ingressClass checks that the Ingress selects its IngressClass with ingressClassName
+func ingressClass(ingress ks.Ingress) (score scorecard.TestScore, err error) {
+	className := ingress.IngressClassName()
+	annotation, hasAnnotation := ingress.GetObjectMeta().Annotations[ingressClassAnnotation]
+
+	switch {
+	case className != nil && hasAnnotation:
+		score.Grade = scorecard.GradeCritical
+		score.AddComment("", "Both ingressClassName and the ingress class annotation is set",
+			fmt.Sprintf("The %s annotation can not be set together with ingressClassName. Remove the annotation.", ingressClassAnnotation))
+	case className != nil:
+		score.Grade = scorecard.GradeAllOK
+	case hasAnnotation:
+		score.Grade = scorecard.GradeAlmostOK
+		score.AddComment("", "The Ingress uses the deprecated ingress class annotation",
+			fmt.Sprintf("The %s annotation is deprecated. Set ingressClassName: %s instead.", ingressClassAnnotation, annotation))
+	default:
+		score.Grade = scorecard.GradeWarning
+		score.AddComment("", "The Ingress does not set ingressClassName",
+			"Without ingressClassName, the Ingress is only served if the cluster has a default IngressClass. Set ingressClassName to select the ingress controller explicitly.")
+	}
+
+	return
+}
+
+func ingressClassOf(ingress ks.Ingress) string {
+	if className := ingress.IngressClassName(); className != nil {
+		return *className
+	}
+	return ingress.GetObjectMeta().Annotations[ingressClassAnnotation]
+}
+
+type hostPath struct {
+	class, host, path string
+	pathType          networkingv1.PathType
+}
+
+func hostPaths(ingress ks.Ingress) []hostPath {
+	class := ingressClassOf(ingress)
+	var res []hostPath
+	for _, rule := range ingress.Rules() {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			p := path.Path
+			if p == "" {
+				p = "/"
+			}
+			pathType := networkingv1.PathTypeImplementationSpecific
+			if path.PathType != nil {
+				pathType = *path.PathType
+			}
+			res = append(res, hostPath{class: class, host: rule.Host, path: p, pathType: pathType})
+		}
+	}
+	return res
+}
+
+// ingressDuplicateHostPath checks that no two Ingresses with the same IngressClass routes the same host and path
+// Ingress controllers handle duplicates differently, and often silently route all traffic to one of the backends.
+func ingressDuplicateHostPath(allIngresses []ks.Ingress) func(ks.Ingress) (scorecard.TestScore, error) {
+	return func(ingress ks.Ingress) (score scorecard.TestScore, err error) {
+		score.Grade = scorecard.GradeAllOK
+
+		self := ingress.GetObjectMeta()
+		seen := make(map[hostPath]struct{})
+
+		for _, hp := range hostPaths(ingress) {
+			var duplicates []string
+			if _, ok := seen[hp]; ok {
+				duplicates = append(duplicates, "this Ingress")
+			}
+			seen[hp] = struct{}{}
+
+			for _, other := range allIngresses {
+				otherMeta := other.GetObjectMeta()
+				if otherMeta.Namespace == self.Namespace && otherMeta.Name == self.Name {
+					continue
+				}
+				for _, otherHP := range hostPaths(other) {
+					if otherHP == hp {
+						name := otherMeta.Name
+						if otherMeta.Namespace != "" {
+							name = otherMeta.Namespace + "/" + name
+						}
+						duplicates = append(duplicates, name)
+						break
+					}
+				}
+			}
+
+			if len(duplicates) > 0 {
+				sort.Strings(duplicates)
+				host := hp.host
+				if host == "" {
+					host = "*"
+				}
+				score.LowerGrade(scorecard.GradeWarning)
+				score.AddComment(host+hp.path, "The host and path is used by another Ingress",
+					fmt.Sprintf("The path %s on host %s is also routed by %s. Ingress controllers handle duplicates differently, and often route all traffic to only one of the backends.", hp.path, host, strings.Join(duplicates, ", ")))
+			}
+		}
+
+		return
+	}
+}
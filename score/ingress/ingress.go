@@ -6,10 +6,16 @@ import (
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/scorecard"
+	networkingv1 "k8s.io/api/networking/v1"
 )
 
-func Register(allChecks *checks.Checks, services ks.Services) {
-	allChecks.RegisterIngressCheck("Ingress targets Service", `Makes sure that the Ingress targets a Service`, ingressTargetsService(services.Services()))
+func Register(allChecks *checks.Checks, services ks.Services, ingresses ks.Ingresses) {
+	allChecks.RegisterIngressCheck("Ingress targets Service", `Makes sure that the Ingress targets a Service, and a port that exists on the Service`, ingressTargetsService(services.Services()))
+	allChecks.RegisterIngressCheck("Ingress Default Backend", `Makes sure that the defaultBackend of the Ingress targets a Service, and a port that exists on the Service`, ingressDefaultBackend(services.Services()))
+	allChecks.RegisterIngressCheck("Ingress TLS", `Makes sure that all TLS hosts of the Ingress are used by a rule, and that the TLS sections have a secretName`, ingressTLS)
+	allChecks.RegisterIngressCheck("Ingress Hosts", `Makes sure that the hosts of the Ingress are valid, and warns about wildcard hosts`, ingressHosts)
+	allChecks.RegisterOptionalIngressCheck("Ingress Class", `Makes sure that the Ingress sets ingressClassName, instead of relying on the default IngressClass or the deprecated kubernetes.io/ingress.class annotation`, ingressClass)
+	allChecks.RegisterIngressCheck("Ingress Duplicate Host Path", `Makes sure that no other Ingress with the same IngressClass uses the same host and path`, ingressDuplicateHostPath(ingresses.Ingresses()))
 }
 
 func ingressTargetsService(allServices []ks.Service) func(ks.Ingress) (scorecard.TestScore, error) {
@@ -18,6 +24,40 @@ func ingressTargetsService(allServices []ks.Service) func(ks.Ingress) (scorecard
 	}
 }
 
+// serviceBackendMatches returns true if the backend targets a Service in the namespace, and a port of that Service
+// Resource backends can not be verified, and are always considered to be a match.
+func serviceBackendMatches(backend networkingv1.IngressBackend, namespace string, allServices []ks.Service) bool {
+	if backend.Service == nil {
+		return backend.Resource != nil
+	}
+
+	for _, srv := range allServices {
+		service := srv.Service()
+		if service.Namespace != namespace || service.Name != backend.Service.Name {
+			continue
+		}
+		for _, servicePort := range service.Spec.Ports {
+			if backend.Service.Port.Number > 0 && servicePort.Port == backend.Service.Port.Number {
+				return true
+			}
+			if backend.Service.Port.Number == 0 && backend.Service.Port.Name != "" && servicePort.Name == backend.Service.Port.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func describeServiceBackend(backend networkingv1.IngressBackend) string {
+	if backend.Service == nil {
+		return ""
+	}
+	if backend.Service.Port.Number > 0 {
+		return fmt.Sprintf("No service with name %s and port number %d was found", backend.Service.Name, backend.Service.Port.Number)
+	}
+	return fmt.Sprintf("No service with name %s and port named %s was found", backend.Service.Name, backend.Service.Port.Name)
+}
+
 func ingressTargetsServiceCommon(ingress ks.Ingress, allServices []ks.Service) (score scorecard.TestScore, err error) {
 	allRulesHaveMatches := true
 
@@ -27,41 +67,9 @@ func ingressTargetsServiceCommon(ingress ks.Ingress, allServices []ks.Service) (
 		}
 
 		for _, path := range rule.IngressRuleValue.HTTP.Paths {
-
-			pathHasMatch := false
-
-			for _, srv := range allServices {
-				service := srv.Service()
-
-				if service.Namespace != ingress.GetObjectMeta().Namespace {
-					continue
-				}
-				if path.Backend.Service == nil {
-					continue
-				}
-
-				if service.Name == path.Backend.Service.Name {
-					for _, servicePort := range service.Spec.Ports {
-						if path.Backend.Service.Port.Number > 0 && servicePort.Port == path.Backend.Service.Port.Number {
-							pathHasMatch = true
-						} else if servicePort.Name == path.Backend.Service.Port.Name {
-							pathHasMatch = true
-						}
-					}
-				}
-			}
-
-			if !pathHasMatch {
+			if !serviceBackendMatches(path.Backend, ingress.GetObjectMeta().Namespace, allServices) {
 				allRulesHaveMatches = false
-				if path.Backend.Service != nil {
-					if path.Backend.Service.Port.Number > 0 {
-						score.AddComment(path.Path, "No service match was found", fmt.Sprintf("No service with name %s and port number %d was found", path.Backend.Service.Name, path.Backend.Service.Port.Number))
-					} else {
-						score.AddComment(path.Path, "No service match was found", fmt.Sprintf("No service with name %s and port named %s was found", path.Backend.Service.Name, path.Backend.Service.Port.Name))
-					}
-				} else {
-					score.AddComment(path.Path, "No service match was found", "")
-				}
+				score.AddComment(path.Path, "No service match was found", describeServiceBackend(path.Backend))
 			}
 		}
 	}
@@ -74,3 +82,24 @@ func ingressTargetsServiceCommon(ingress ks.Ingress, allServices []ks.Service) (
 
 	return
 }
+
+func ingressDefaultBackend(allServices []ks.Service) func(ks.Ingress) (scorecard.TestScore, error) {
+	return func(ingress ks.Ingress) (score scorecard.TestScore, err error) {
+		backend := ingress.DefaultBackend()
+		if backend == nil {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because the Ingress has no defaultBackend", "")
+			return
+		}
+
+		if serviceBackendMatches(*backend, ingress.GetObjectMeta().Namespace, allServices) {
+			score.Grade = scorecard.GradeAllOK
+			return
+		}
+
+		score.Grade = scorecard.GradeCritical
+		score.AddComment("", "The defaultBackend does not match any service", describeServiceBackend(*backend))
+		return
+	}
+}
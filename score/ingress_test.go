@@ -3,6 +3,10 @@ package score
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -50,3 +54,93 @@ func TestIngressNoPanicIssue388(t *testing.T) {
 	t.Parallel()
 	testExpectedScore(t, "ingress_issue388.yaml", "Ingress targets Service", scorecard.GradeAllOK)
 }
+
+func TestIngressV1BackendPortMissing(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "ingress-networkingv1-backend-port-missing.yaml", "Ingress targets Service", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "No service with name app and port number 8080 was found", comments[0].Description)
+}
+
+func TestIngressV1beta1DefaultBackendMissing(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "ingress-networkingv1beta1-default-backend-missing.yaml", "Ingress Default Backend", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "No service with name app and port named http was found", comments[0].Description)
+}
+
+func TestIngressExtensionsV1beta1DefaultBackend(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "ingress-extensionsv1beta1-default-backend.yaml", "Ingress Default Backend", scorecard.GradeAllOK)
+}
+
+func TestIngressNoDefaultBackend(t *testing.T) {
+	t.Parallel()
+	assert.True(t, wasSkipped(t, []ks.NamedReader{testFile("ingress-networkingv1-tls.yaml")}, nil, nil, "Ingress Default Backend"))
+}
+
+func TestIngressTLS(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "ingress-networkingv1-tls.yaml", "Ingress TLS", scorecard.GradeWarning)
+	assert.Equal(t, []scorecard.TestScoreComment{
+		{
+			Path:        "typo.example.com",
+			Summary:     "The TLS host is not used by any rule",
+			Description: "The host is listed in the TLS configuration, but no rule routes traffic for it. This is often caused by a typo in the TLS host or the rule host.",
+		},
+		{
+			Path:        "*.example.org",
+			Summary:     "The TLS configuration has no secretName",
+			Description: "Without a secretName, the default certificate of the ingress controller is used, which is likely to not be valid for the hosts.",
+		},
+	}, comments)
+}
+
+func TestIngressTLSSkipped(t *testing.T) {
+	t.Parallel()
+	assert.True(t, wasSkipped(t, []ks.NamedReader{testFile("ingress-networkingv1-hosts.yaml")}, nil, nil, "Ingress TLS"))
+}
+
+func TestIngressHosts(t *testing.T) {
+	t.Parallel()
+	summaries := getSummaries(t, []ks.NamedReader{testFile("ingress-networkingv1-hosts.yaml")}, nil, nil, "Ingress Hosts")
+	assert.Equal(t, []string{"The host is a wildcard", "The wildcard host is invalid", "The host is an IP address"}, summaries)
+	testExpectedScore(t, "ingress-networkingv1-hosts.yaml", "Ingress Hosts", scorecard.GradeCritical)
+	testExpectedScore(t, "ingress-networkingv1-tls.yaml", "Ingress Hosts", scorecard.GradeAllOK)
+}
+
+func TestIngressClass(t *testing.T) {
+	t.Parallel()
+	runConfig := &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"ingress-class": {}},
+	}
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("ingress-networkingv1-tls.yaml")}, nil, runConfig, "Ingress Class", scorecard.GradeAllOK)
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("ingress-extensionsv1beta1-default-backend.yaml")}, nil, runConfig, "Ingress Class", scorecard.GradeAlmostOK)
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("ingress-targets-service.yaml")}, nil, runConfig, "Ingress Class", scorecard.GradeWarning)
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("ingress-networkingv1-hosts.yaml")}, nil, runConfig, "Ingress Class", scorecard.GradeCritical)
+}
+
+func TestIngressDuplicateHostPath(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore([]ks.NamedReader{testFile("ingress-networkingv1-duplicate-host-path.yaml")}, nil, nil)
+	assert.NoError(t, err)
+
+	grades := make(map[string]scorecard.Grade)
+	for _, obj := range sc {
+		for _, c := range obj.Checks {
+			if c.Check.Name == "Ingress Duplicate Host Path" {
+				grades[obj.ObjectMeta.Name] = c.Grade
+				if obj.ObjectMeta.Name == "app-a" {
+					assert.Equal(t, "app.example.com/api", c.Comments[0].Path)
+					assert.Contains(t, c.Comments[0].Description, "is also routed by app-b.")
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]scorecard.Grade{
+		"app-a": scorecard.GradeWarning,
+		"app-b": scorecard.GradeWarning,
+		"app-c": scorecard.GradeAllOK,
+	}, grades)
+}
@@ -0,0 +1,27 @@
+package internal
+
+import corev1 "k8s.io/api/core/v1"
+
+// AllContainers returns the init containers, containers, and ephemeral containers of the PodSpec.
+// Ephemeral containers are converted to regular containers, as they share the same fields.
+func AllContainers(spec corev1.PodSpec) []corev1.Container {
+	allContainers := make([]corev1.Container, 0, len(spec.InitContainers)+len(spec.Containers)+len(spec.EphemeralContainers))
+	allContainers = append(allContainers, spec.InitContainers...)
+	allContainers = append(allContainers, spec.Containers...)
+	for _, ec := range spec.EphemeralContainers {
+		allContainers = append(allContainers, corev1.Container(ec.EphemeralContainerCommon))
+	}
+	return allContainers
+}
+
+// RunningContainers returns the containers and sidecar containers of the PodSpec, that is the containers that
+// keep running for the lifetime of the pod, and that can have probes and serve traffic.
+func RunningContainers(spec corev1.PodSpec) []corev1.Container {
+	var res []corev1.Container
+	for _, c := range AllContainers(spec) {
+		if IsSidecar(c) {
+			res = append(res, c)
+		}
+	}
+	return append(res, spec.Containers...)
+}
@@ -0,0 +1,65 @@
+package internal
+
+import "strings"
+
+const defaultRegistry = "docker.io"
+
+// ImageReference is a parsed container image reference, such as "registry:5000/org/app:v1@sha256:abc"
+type ImageReference struct {
+	// Registry is the registry host, including the port if set. Images without a registry default to "docker.io"
+	Registry string
+	// Repository is the path of the image in the registry, such as "org/app" or "library/nginx"
+	Repository string
+	// Tag is empty if the image reference has no tag
+	Tag string
+	// Digest is on the format "sha256:abc", and empty if the image reference is not pinned
+	Digest string
+}
+
+// Name returns the fully qualified name of the image, without tag or digest
+func (r ImageReference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// ParseImageReference parses a container image reference in the same way as the container runtime
+//
+// A port in the registry host is not mistaken for a tag, "registry:5000/img" has no tag.
+func ParseImageReference(image string) ImageReference {
+	var ref ImageReference
+
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+	}
+
+	// The tag is separated by the last colon, but only if it's in the last path component
+	if i := strings.LastIndex(name, ":"); i >= 0 && i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	// The first path component is a registry if it looks like a hostname
+	if i := strings.Index(name, "/"); i >= 0 {
+		first := name[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" || strings.ToLower(first) != first {
+			ref.Registry = first
+			ref.Repository = name[i+1:]
+		}
+	}
+
+	if ref.Registry == "" {
+		ref.Registry = defaultRegistry
+		ref.Repository = name
+		if !strings.Contains(name, "/") {
+			ref.Repository = "library/" + name
+		}
+	}
+
+	// Legacy alias of docker.io
+	if ref.Registry == "index.docker.io" {
+		ref.Registry = defaultRegistry
+	}
+
+	return ref
+}
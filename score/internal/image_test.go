@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImageReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		image    string
+		expected ImageReference
+	}{
+		{"nginx", ImageReference{Registry: "docker.io", Repository: "library/nginx"}},
+		{"nginx:1.25", ImageReference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"}},
+		{"foo/bar:latest", ImageReference{Registry: "docker.io", Repository: "foo/bar", Tag: "latest"}},
+		{"registry:5000/img", ImageReference{Registry: "registry:5000", Repository: "img"}},
+		{"registry:5000/img:v1", ImageReference{Registry: "registry:5000", Repository: "img", Tag: "v1"}},
+		{"localhost/img", ImageReference{Registry: "localhost", Repository: "img"}},
+		{"gcr.io/org/team/app:1.0", ImageReference{Registry: "gcr.io", Repository: "org/team/app", Tag: "1.0"}},
+		{"index.docker.io/library/nginx", ImageReference{Registry: "docker.io", Repository: "library/nginx"}},
+		{"nginx@sha256:0123abcd", ImageReference{Registry: "docker.io", Repository: "library/nginx", Digest: "sha256:0123abcd"}},
+		{"quay.io/app:v2@sha256:0123abcd", ImageReference{Registry: "quay.io", Repository: "app", Tag: "v2", Digest: "sha256:0123abcd"}},
+		{"registry:5000/img@sha256:0123abcd", ImageReference{Registry: "registry:5000", Repository: "img", Digest: "sha256:0123abcd"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.image, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ParseImageReference(tc.image))
+		})
+	}
+}
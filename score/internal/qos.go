@@ -0,0 +1,78 @@
+package internal
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+var qosResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// PodQOSClass calculates the QoS class of a pod, in the same way as the kubelet
+//
+// Init containers (including sidecars) are included. Requests that are not set default to the limit, as they would
+// when the pod is created. The pod overhead does not affect the QoS class.
+func PodQOSClass(spec corev1.PodSpec) corev1.PodQOSClass {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	isGuaranteed := true
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		containerRequests := container.Resources.Requests
+		containerLimits := container.Resources.Limits
+
+		limitsFound := 0
+		for _, name := range qosResources {
+			request, hasRequest := containerRequests[name]
+			limit, hasLimit := containerLimits[name]
+			if !hasRequest && hasLimit {
+				request, hasRequest = limit, true
+			}
+
+			if hasRequest && request.Sign() > 0 {
+				addResourceList(requests, corev1.ResourceList{name: request})
+			}
+			if hasLimit && limit.Sign() > 0 {
+				addResourceList(limits, corev1.ResourceList{name: limit})
+				limitsFound++
+			}
+		}
+
+		if limitsFound != len(qosResources) {
+			isGuaranteed = false
+		}
+	}
+
+	if len(requests) == 0 && len(limits) == 0 {
+		return corev1.PodQOSBestEffort
+	}
+
+	if isGuaranteed {
+		for name, request := range requests {
+			if limit, ok := limits[name]; !ok || limit.Cmp(request) != 0 {
+				isGuaranteed = false
+				break
+			}
+		}
+	}
+
+	if isGuaranteed && len(requests) == len(limits) {
+		return corev1.PodQOSGuaranteed
+	}
+
+	return corev1.PodQOSBurstable
+}
+
+// QOSClassRank orders the QoS classes from the lowest (BestEffort) to the highest (Guaranteed)
+// The second return value is false if the class is unknown.
+func QOSClassRank(class corev1.PodQOSClass) (int, bool) {
+	switch class {
+	case corev1.PodQOSBestEffort:
+		return 0, true
+	case corev1.PodQOSBurstable:
+		return 1, true
+	case corev1.PodQOSGuaranteed:
+		return 2, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func resources(requests, limits map[corev1.ResourceName]string) corev1.ResourceRequirements {
+	r := corev1.ResourceRequirements{}
+	if requests != nil {
+		r.Requests = corev1.ResourceList{}
+		for k, v := range requests {
+			r.Requests[k] = resource.MustParse(v)
+		}
+	}
+	if limits != nil {
+		r.Limits = corev1.ResourceList{}
+		for k, v := range limits {
+			r.Limits[k] = resource.MustParse(v)
+		}
+	}
+	return r
+}
+
+func TestPodQOSClass(t *testing.T) {
+	t.Parallel()
+
+	guaranteed := corev1.Container{Name: "a", Resources: resources(
+		map[corev1.ResourceName]string{corev1.ResourceCPU: "100m", corev1.ResourceMemory: "128Mi"},
+		map[corev1.ResourceName]string{corev1.ResourceCPU: "100m", corev1.ResourceMemory: "128Mi"},
+	)}
+	limitsOnly := corev1.Container{Name: "b", Resources: resources(
+		nil,
+		map[corev1.ResourceName]string{corev1.ResourceCPU: "1", corev1.ResourceMemory: "1Gi"},
+	)}
+	burstable := corev1.Container{Name: "c", Resources: resources(
+		map[corev1.ResourceName]string{corev1.ResourceCPU: "100m"},
+		map[corev1.ResourceName]string{corev1.ResourceCPU: "200m", corev1.ResourceMemory: "128Mi"},
+	)}
+	empty := corev1.Container{Name: "d"}
+
+	tests := []struct {
+		name     string
+		spec     corev1.PodSpec
+		expected corev1.PodQOSClass
+	}{
+		{"no resources", corev1.PodSpec{Containers: []corev1.Container{empty}}, corev1.PodQOSBestEffort},
+		{"requests equal limits", corev1.PodSpec{Containers: []corev1.Container{guaranteed}}, corev1.PodQOSGuaranteed},
+		{"requests default to limits", corev1.PodSpec{Containers: []corev1.Container{limitsOnly, guaranteed}}, corev1.PodQOSGuaranteed},
+		{"requests lower than limits", corev1.PodSpec{Containers: []corev1.Container{burstable}}, corev1.PodQOSBurstable},
+		{"one container without resources", corev1.PodSpec{Containers: []corev1.Container{guaranteed, empty}}, corev1.PodQOSBurstable},
+		{"init container without resources", corev1.PodSpec{InitContainers: []corev1.Container{empty}, Containers: []corev1.Container{guaranteed}}, corev1.PodQOSBurstable},
+		{"only init container has resources", corev1.PodSpec{InitContainers: []corev1.Container{guaranteed}, Containers: []corev1.Container{empty}}, corev1.PodQOSBurstable},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, PodQOSClass(tc.spec))
+		})
+	}
+}
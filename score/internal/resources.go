@@ -0,0 +1,73 @@
+package internal
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodResources calculates the effective requests or limits of a pod, in the same way as the scheduler and kubelet
+//
+// The effective value is the highest of the sum of all containers and restartable init containers (sidecars), and the
+// highest value of any regular init container together with the sidecars started before it. The pod overhead is added
+// on top. pick selects if requests or limits are calculated.
+func PodResources(spec corev1.PodSpec, pick func(corev1.ResourceRequirements) corev1.ResourceList) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, container := range spec.Containers {
+		addResourceList(total, pick(container.Resources))
+	}
+
+	sidecars := corev1.ResourceList{}
+	initMax := corev1.ResourceList{}
+	for _, container := range spec.InitContainers {
+		if IsSidecar(container) {
+			addResourceList(sidecars, pick(container.Resources))
+			continue
+		}
+		// Regular init containers run one at a time, together with the sidecars that have been started before them
+		running := pick(container.Resources).DeepCopy()
+		if running == nil {
+			running = corev1.ResourceList{}
+		}
+		addResourceList(running, sidecars)
+		maxResourceList(initMax, running)
+	}
+
+	addResourceList(total, sidecars)
+	maxResourceList(total, initMax)
+	addResourceList(total, spec.Overhead)
+
+	return total
+}
+
+// Requests picks the requests from ResourceRequirements, for use with PodResources
+func Requests(r corev1.ResourceRequirements) corev1.ResourceList {
+	return r.Requests
+}
+
+// Limits picks the limits from ResourceRequirements, for use with PodResources
+func Limits(r corev1.ResourceRequirements) corev1.ResourceList {
+	return r.Limits
+}
+
+// IsSidecar returns true if the container is an init container with restartPolicy Always
+func IsSidecar(container corev1.Container) bool {
+	return container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
+func addResourceList(list, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func maxResourceList(list, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if value, ok := list[name]; !ok || quantity.Cmp(value) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
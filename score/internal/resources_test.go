@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func cpuRequest(name, cpu string) corev1.Container {
+	return corev1.Container{
+		Name: name,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+		},
+	}
+}
+
+func TestPodResources(t *testing.T) {
+	t.Parallel()
+
+	always := corev1.ContainerRestartPolicyAlways
+	sidecar := cpuRequest("sidecar", "200m")
+	sidecar.RestartPolicy = &always
+
+	tests := []struct {
+		name     string
+		spec     corev1.PodSpec
+		expected string
+	}{
+		{
+			name:     "containers are summed",
+			spec:     corev1.PodSpec{Containers: []corev1.Container{cpuRequest("a", "100m"), cpuRequest("b", "250m")}},
+			expected: "350m",
+		},
+		{
+			name: "init container larger than containers",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{cpuRequest("init", "1")},
+				Containers:     []corev1.Container{cpuRequest("a", "100m"), cpuRequest("b", "250m")},
+			},
+			expected: "1",
+		},
+		{
+			name: "sidecars are added to containers and later init containers",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{sidecar, cpuRequest("init", "1")},
+				Containers:     []corev1.Container{cpuRequest("a", "100m")},
+			},
+			expected: "1200m",
+		},
+		{
+			name: "overhead",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{cpuRequest("a", "100m")},
+				Overhead:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+			},
+			expected: "150m",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := PodResources(tc.spec, Requests)
+			expected := resource.MustParse(tc.expected)
+			assert.Equal(t, 0, expected.Cmp(*res.Cpu()), "expected=%s got=%s", tc.expected, res.Cpu().String())
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package internal
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodIsTargetedByService returns true if the pod is in the same namespace as the service, and matches the selector of the service
+func PodIsTargetedByService(pod corev1.PodTemplateSpec, service corev1.Service) bool {
+	if pod.Namespace != service.Namespace {
+		return false
+	}
+
+	return LabelSelectorMatchesLabels(
+		service.Spec.Selector,
+		pod.GetObjectMeta().GetLabels(),
+	)
+}
@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodIsTargetedByService(t *testing.T) {
+	pod := v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foospace",
+			Labels:    map[string]string{"foo": "bar"},
+		},
+	}
+
+	assert.True(t, PodIsTargetedByService(pod, v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foospace"},
+		Spec:       v1.ServiceSpec{Selector: map[string]string{"foo": "bar"}},
+	}))
+	assert.False(t, PodIsTargetedByService(pod, v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "someOtherNamespace"},
+		Spec:       v1.ServiceSpec{Selector: map[string]string{"foo": "bar"}},
+	}))
+}
@@ -0,0 +1,130 @@
+package lifecycle
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/scorecard"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	defaultTerminationGracePeriodSeconds    = 30
+	defaultMaxTerminationGracePeriodSeconds = 600
+)
+
+func Register(allChecks *checks.Checks, services ks.Services, policy config.ShutdownPolicy) {
+	allChecks.RegisterOptionalPodCheck("Pod Graceful Shutdown", `Makes sure that pods targeted by a Service have a preStop hook, and a terminationGracePeriodSeconds that is longer than the preStop hook`, podGracefulShutdown(services.Services(), policy))
+}
+
+// podGracefulShutdown checks that pods targeted by a Service can shut down without dropping connections
+//
+// Endpoints are removed from the Service at the same time as the containers are stopped. A preStop hook that
+// waits for a few seconds gives the load balancers time to stop sending traffic to the pod. The
+// terminationGracePeriodSeconds must be longer than the preStop hook, otherwise the container is killed before the
+// hook has finished.
+func podGracefulShutdown(allServices []ks.Service, policy config.ShutdownPolicy) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	maxGracePeriod := policy.MaxTerminationGracePeriod
+	if maxGracePeriod == 0 {
+		maxGracePeriod = defaultMaxTerminationGracePeriodSeconds
+	}
+
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		typeMeta := ps.GetTypeMeta()
+		if typeMeta.GroupVersionKind().Group == "batch" && (typeMeta.Kind == "CronJob" || typeMeta.Kind == "Job") {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because Jobs are not targeted by Services", "")
+			return
+		}
+
+		podTemplate := ps.GetPodTemplateSpec()
+
+		isTargetedByService := false
+		for _, s := range allServices {
+			if internal.PodIsTargetedByService(podTemplate, s.Service()) {
+				isTargetedByService = true
+				break
+			}
+		}
+		if !isTargetedByService {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "The pod is not targeted by a service, skipping graceful shutdown checks.", "")
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		gracePeriod := int64(defaultTerminationGracePeriodSeconds)
+		if podTemplate.Spec.TerminationGracePeriodSeconds != nil {
+			gracePeriod = *podTemplate.Spec.TerminationGracePeriodSeconds
+		}
+
+		switch {
+		case gracePeriod == 0:
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("", "terminationGracePeriodSeconds is 0",
+				"The containers are killed immediately when the pod is deleted, without any time to finish in-flight requests.")
+		case gracePeriod > maxGracePeriod:
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("", fmt.Sprintf("terminationGracePeriodSeconds is %d", gracePeriod),
+				fmt.Sprintf("A grace period longer than %d seconds makes rollouts and node drains very slow, and a stuck container blocks them for the entire period.", maxGracePeriod))
+		}
+
+		hasPreStop := false
+		for _, container := range podTemplate.Spec.Containers {
+			if container.Lifecycle == nil || container.Lifecycle.PreStop == nil {
+				continue
+			}
+			hasPreStop = true
+
+			if duration, ok := preStopDuration(container.Lifecycle.PreStop); ok && duration >= gracePeriod {
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.Name, "The preStop hook is not shorter than terminationGracePeriodSeconds",
+					fmt.Sprintf("The preStop hook waits for %d seconds, and the grace period is %d seconds. The container is killed before it has been stopped gracefully. "+
+						"Increase terminationGracePeriodSeconds to give the application time to shut down after the hook.", duration, gracePeriod))
+			}
+		}
+
+		if !hasPreStop {
+			score.LowerGrade(scorecard.GradeWarning)
+			score.AddCommentWithURL("", "No container has a preStop hook",
+				"The pod is removed from the Service endpoints at the same time as the containers are stopped, and can receive traffic after it has started to shut down. "+
+					"Add a preStop hook that sleeps for a few seconds, to avoid dropping connections during rollouts.",
+				"https://kubernetes.io/docs/concepts/containers/container-lifecycle-hooks/")
+		}
+
+		return
+	}
+}
+
+var sleepCommand = regexp.MustCompile(`(?:^|[\s;&|])sleep\s+(\d+)s?(?:$|[\s;&|])`)
+
+// preStopDuration returns the number of seconds the preStop hook waits for
+// The second return value is false if the duration is unknown, such as for HTTP hooks.
+func preStopDuration(handler *corev1.LifecycleHandler) (int64, bool) {
+	if handler.Sleep != nil {
+		return handler.Sleep.Seconds, true
+	}
+
+	if handler.Exec != nil {
+		m := sleepCommand.FindStringSubmatch(strings.Join(handler.Exec.Command, " "))
+		if m == nil {
+			return 0, false
+		}
+		seconds, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return seconds, true
+	}
+
+	return 0, false
+}
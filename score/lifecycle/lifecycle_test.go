@@ -0,0 +1,34 @@
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPreStopDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		handler  corev1.LifecycleHandler
+		expected int64
+		ok       bool
+	}{
+		{"sleep action", corev1.LifecycleHandler{Sleep: &corev1.SleepAction{Seconds: 5}}, 5, true},
+		{"sleep command", corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"sleep", "10"}}}, 10, true},
+		{"sleep in shell", corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", "sleep 15s && kill -TERM 1"}}}, 15, true},
+		{"other command", corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/app/drain"}}}, 0, false},
+		{"not a sleep command", corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/app/nosleep", "20"}}}, 0, false},
+		{"http", corev1.LifecycleHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/drain"}}, 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			duration, ok := preStopDuration(&tc.handler)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, duration)
+		})
+	}
+}
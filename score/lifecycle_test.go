@@ -0,0 +1,63 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestPodGracefulShutdown(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("deployment-graceful-shutdown.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-graceful-shutdown": {}},
+	}, "Pod Graceful Shutdown", scorecard.GradeAllOK)
+}
+
+func TestPodGracefulShutdownMissingPreStop(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("deployment-graceful-shutdown-missing-prestop.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-graceful-shutdown": {}},
+	}, "Pod Graceful Shutdown", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "No container has a preStop hook", comments[0].Summary)
+}
+
+func TestPodGracefulShutdownLongPreStop(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("deployment-graceful-shutdown-long-prestop.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-graceful-shutdown": {}},
+	}, "Pod Graceful Shutdown", scorecard.GradeCritical)
+	assert.Equal(t, []scorecard.TestScoreComment{{
+		Path:        "app",
+		Summary:     "The preStop hook is not shorter than terminationGracePeriodSeconds",
+		Description: "The preStop hook waits for 40 seconds, and the grace period is 30 seconds. The container is killed before it has been stopped gracefully. Increase terminationGracePeriodSeconds to give the application time to shut down after the hook.",
+	}}, comments)
+}
+
+func TestPodGracefulShutdownZeroGracePeriod(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("deployment-graceful-shutdown-zero-grace.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-graceful-shutdown": {}},
+	}, "Pod Graceful Shutdown", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "terminationGracePeriodSeconds is 0", comments[0].Summary)
+}
+
+func TestPodGracefulShutdownLargeGracePeriod(t *testing.T) {
+	t.Parallel()
+	summaries := getSummaries(t, []ks.NamedReader{testFile("deployment-graceful-shutdown.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-graceful-shutdown": {}},
+		ShutdownPolicy:       config.ShutdownPolicy{MaxTerminationGracePeriod: 40},
+	}, "Pod Graceful Shutdown")
+	assert.Equal(t, []string{"terminationGracePeriodSeconds is 45"}, summaries)
+}
+
+func TestPodGracefulShutdownNotTargetedByService(t *testing.T) {
+	t.Parallel()
+	assert.True(t, wasSkipped(t, []ks.NamedReader{testFile("pod-probes-validation.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-graceful-shutdown": {}},
+	}, "Pod Graceful Shutdown"))
+}
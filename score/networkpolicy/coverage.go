@@ -0,0 +1,326 @@
+package networkpolicy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score/internal"
+)
+
+// policyTypes returns if the policy applies to ingress and/or egress traffic
+//
+// If PolicyTypes is not set, all policies apply to ingress, and policies with egress rules also apply to egress.
+func policyTypes(netpol networkingv1.NetworkPolicy) (ingress, egress bool) {
+	if len(netpol.Spec.PolicyTypes) == 0 {
+		return true, len(netpol.Spec.Egress) > 0
+	}
+	for _, policyType := range netpol.Spec.PolicyTypes {
+		switch policyType {
+		case networkingv1.PolicyTypeIngress:
+			ingress = true
+		case networkingv1.PolicyTypeEgress:
+			egress = true
+		}
+	}
+	return
+}
+
+// defaultDeny returns if the policy denies all ingress and/or egress traffic for all pods in the namespace
+func defaultDeny(netpol networkingv1.NetworkPolicy) (ingress, egress bool) {
+	if len(netpol.Spec.PodSelector.MatchLabels) > 0 || len(netpol.Spec.PodSelector.MatchExpressions) > 0 {
+		return false, false
+	}
+	appliesToIngress, appliesToEgress := policyTypes(netpol)
+	return appliesToIngress && len(netpol.Spec.Ingress) == 0, appliesToEgress && len(netpol.Spec.Egress) == 0
+}
+
+func selectorMatches(selector *metav1.LabelSelector, labels map[string]string) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(internal.MapLabels(labels))
+}
+
+func isEmptySelector(selector *metav1.LabelSelector) bool {
+	return selector != nil && len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0
+}
+
+type workload struct {
+	kind, name, namespace string
+	labels                map[string]string
+}
+
+func allWorkloads(pods []ks.Pod, podspecers []ks.PodSpecer) []workload {
+	var res []workload
+	for _, p := range pods {
+		pod := p.Pod()
+		res = append(res, workload{kind: pod.Kind, name: pod.Name, namespace: pod.Namespace, labels: pod.Labels})
+	}
+	for _, ps := range podspecers {
+		template := ps.GetPodTemplateSpec()
+		res = append(res, workload{kind: ps.GetTypeMeta().Kind, name: ps.GetObjectMeta().Name, namespace: template.Namespace, labels: template.Labels})
+	}
+	return res
+}
+
+// knownNamespaces returns the labels of all namespaces that are defined, or that have objects in them
+// All namespaces have the kubernetes.io/metadata.name label, which is set by the API server.
+func knownNamespaces(namespaces []ks.Namespace, workloads []workload, netpols []ks.NetworkPolicy) map[string]map[string]string {
+	res := make(map[string]map[string]string)
+	add := func(name string, labels map[string]string) {
+		if _, ok := res[name]; !ok {
+			res[name] = map[string]string{corev1.LabelMetadataName: name}
+		}
+		for k, v := range labels {
+			res[name][k] = v
+		}
+	}
+	for _, n := range namespaces {
+		add(n.Namespace().Name, n.Namespace().Labels)
+	}
+	for _, w := range workloads {
+		add(w.namespace, nil)
+	}
+	for _, n := range netpols {
+		add(n.NetworkPolicy().Namespace, nil)
+	}
+	return res
+}
+
+// isAnywhere returns true if the peers allow traffic from or to anywhere, including outside of the cluster
+func isAnywhere(peers []networkingv1.NetworkPolicyPeer) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, peer := range peers {
+		if peer.IPBlock != nil && len(peer.IPBlock.Except) == 0 &&
+			(peer.IPBlock.CIDR == "0.0.0.0/0" || peer.IPBlock.CIDR == "::/0") {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllowAll returns true if the peers allow traffic from or to anywhere, or from or to all pods in all namespaces
+func isAllowAll(peers []networkingv1.NetworkPolicyPeer) bool {
+	if isAnywhere(peers) {
+		return true
+	}
+	for _, peer := range peers {
+		if peer.IPBlock == nil && isEmptySelector(peer.NamespaceSelector) &&
+			(peer.PodSelector == nil || isEmptySelector(peer.PodSelector)) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeSelector(selector *metav1.LabelSelector) string {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "<invalid selector>"
+	}
+	return s.String()
+}
+
+func describePeer(peer networkingv1.NetworkPolicyPeer) string {
+	if peer.IPBlock != nil {
+		if len(peer.IPBlock.Except) > 0 {
+			return fmt.Sprintf("%s except %s", peer.IPBlock.CIDR, strings.Join(peer.IPBlock.Except, ", "))
+		}
+		return peer.IPBlock.CIDR
+	}
+
+	pods := "all pods"
+	if peer.PodSelector != nil && !isEmptySelector(peer.PodSelector) {
+		pods = "pods " + describeSelector(peer.PodSelector)
+	}
+
+	switch {
+	case peer.NamespaceSelector == nil:
+		return pods
+	case isEmptySelector(peer.NamespaceSelector):
+		return pods + " in all namespaces"
+	default:
+		return pods + " in namespaces " + describeSelector(peer.NamespaceSelector)
+	}
+}
+
+func describePeers(peers []networkingv1.NetworkPolicyPeer) string {
+	if isAnywhere(peers) {
+		return "anywhere"
+	}
+	var res []string
+	for _, peer := range peers {
+		res = append(res, describePeer(peer))
+	}
+	return strings.Join(res, "; ")
+}
+
+func describePorts(ports []networkingv1.NetworkPolicyPort) string {
+	if len(ports) == 0 {
+		return "all ports"
+	}
+	var res []string
+	for _, port := range ports {
+		protocol := corev1.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = *port.Protocol
+		}
+		switch {
+		case port.Port == nil:
+			res = append(res, fmt.Sprintf("all %s ports", protocol))
+		case port.EndPort != nil:
+			res = append(res, fmt.Sprintf("%s-%d/%s", port.Port.String(), *port.EndPort, protocol))
+		default:
+			res = append(res, fmt.Sprintf("%s/%s", port.Port.String(), protocol))
+		}
+	}
+	return strings.Join(res, ", ")
+}
+
+func hasWorkloads(namespace string, workloads []workload) bool {
+	for _, w := range workloads {
+		if w.namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// peerMatchError returns a description of why the peer does not match any pod or namespace, or an empty string if
+// it matches, or if it can not be verified
+func peerMatchError(peer networkingv1.NetworkPolicyPeer, policyNamespace string, namespaces map[string]map[string]string, workloads []workload) string {
+	if peer.IPBlock != nil {
+		return ""
+	}
+
+	matchingNamespaces := map[string]struct{}{policyNamespace: {}}
+	if peer.NamespaceSelector != nil {
+		matchingNamespaces = make(map[string]struct{})
+		for name, labels := range namespaces {
+			if selectorMatches(peer.NamespaceSelector, labels) {
+				matchingNamespaces[name] = struct{}{}
+			}
+		}
+		// Pods in namespaces that are not part of the input can't be verified. The namespace is most likely defined
+		// outside of the input, such as kube-system for DNS.
+		for name := range matchingNamespaces {
+			if !hasWorkloads(name, workloads) {
+				delete(matchingNamespaces, name)
+			}
+		}
+		if len(matchingNamespaces) == 0 {
+			return ""
+		}
+	}
+
+	if peer.PodSelector == nil || isEmptySelector(peer.PodSelector) {
+		return ""
+	}
+
+	for _, w := range workloads {
+		if _, ok := matchingNamespaces[w.namespace]; ok && selectorMatches(peer.PodSelector, w.labels) {
+			return ""
+		}
+	}
+
+	if peer.NamespaceSelector == nil {
+		return fmt.Sprintf("The podSelector %s does not match any pod in the namespace of the policy", describeSelector(peer.PodSelector))
+	}
+	return fmt.Sprintf("The podSelector %s does not match any pod in the namespaces matched by %s", describeSelector(peer.PodSelector), describeSelector(peer.NamespaceSelector))
+}
+
+// NamespaceSummary is the NetworkPolicy coverage of all workloads in a namespace
+type NamespaceSummary struct {
+	Namespace          string
+	DefaultDenyIngress bool
+	DefaultDenyEgress  bool
+	Workloads          []WorkloadSummary
+}
+
+// WorkloadSummary describes which traffic a workload allows
+// If the workload is not isolated for a direction, all traffic is allowed in that direction.
+type WorkloadSummary struct {
+	Kind            string
+	Name            string
+	IngressIsolated bool
+	EgressIsolated  bool
+	Ingress         []Exposure
+	Egress          []Exposure
+}
+
+// Exposure is a rule of a NetworkPolicy that allows traffic to or from a workload
+type Exposure struct {
+	Policy string
+	Ports  string
+	Peers  string
+}
+
+// Summarize returns the NetworkPolicy coverage of all workloads, grouped by namespace and sorted by name
+func Summarize(netpols ks.NetworkPolicies, pods ks.Pods, podspecers ks.PodSpeccers) []NamespaceSummary {
+	summaries := make(map[string]*NamespaceSummary)
+	summaryFor := func(namespace string) *NamespaceSummary {
+		if _, ok := summaries[namespace]; !ok {
+			summaries[namespace] = &NamespaceSummary{Namespace: namespace}
+		}
+		return summaries[namespace]
+	}
+
+	for _, n := range netpols.NetworkPolicies() {
+		netpol := n.NetworkPolicy()
+		denyIngress, denyEgress := defaultDeny(netpol)
+		summary := summaryFor(netpol.Namespace)
+		summary.DefaultDenyIngress = summary.DefaultDenyIngress || denyIngress
+		summary.DefaultDenyEgress = summary.DefaultDenyEgress || denyEgress
+	}
+
+	for _, w := range allWorkloads(pods.Pods(), podspecers.PodSpeccers()) {
+		ws := WorkloadSummary{Kind: w.kind, Name: w.name}
+
+		for _, n := range netpols.NetworkPolicies() {
+			netpol := n.NetworkPolicy()
+			if netpol.Namespace != w.namespace || !selectorMatches(&netpol.Spec.PodSelector, w.labels) {
+				continue
+			}
+			ingress, egress := policyTypes(netpol)
+			if ingress {
+				ws.IngressIsolated = true
+				for _, rule := range netpol.Spec.Ingress {
+					ws.Ingress = append(ws.Ingress, Exposure{Policy: netpol.Name, Ports: describePorts(rule.Ports), Peers: describePeers(rule.From)})
+				}
+			}
+			if egress {
+				ws.EgressIsolated = true
+				for _, rule := range netpol.Spec.Egress {
+					ws.Egress = append(ws.Egress, Exposure{Policy: netpol.Name, Ports: describePorts(rule.Ports), Peers: describePeers(rule.To)})
+				}
+			}
+		}
+
+		summary := summaryFor(w.namespace)
+		summary.Workloads = append(summary.Workloads, ws)
+	}
+
+	var res []NamespaceSummary
+	for _, s := range summaries {
+		sort.Slice(s.Workloads, func(i, j int) bool {
+			if s.Workloads[i].Kind != s.Workloads[j].Kind {
+				return s.Workloads[i].Kind < s.Workloads[j].Kind
+			}
+			return s.Workloads[i].Name < s.Workloads[j].Name
+		})
+		res = append(res, *s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Namespace < res[j].Namespace
+	})
+	return res
+}
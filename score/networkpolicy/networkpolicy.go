@@ -1,6 +1,8 @@
 package networkpolicy
 
 import (
+	"fmt"
+
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -10,9 +12,12 @@ import (
 	"github.com/zegl/kube-score/scorecard"
 )
 
-func Register(allChecks *checks.Checks, netpols ks.NetworkPolicies, pods ks.Pods, podspecers ks.PodSpeccers) {
+func Register(allChecks *checks.Checks, netpols ks.NetworkPolicies, pods ks.Pods, podspecers ks.PodSpeccers, namespaces ks.Namespaces) {
 	allChecks.RegisterPodCheck("Pod NetworkPolicy", `Makes sure that all Pods are targeted by a NetworkPolicy`, podHasNetworkPolicy(netpols.NetworkPolicies()))
+	allChecks.RegisterOptionalPodCheck("Pod NetworkPolicy Default Deny", `Makes sure that the namespace of all Pods has a NetworkPolicy that denies all ingress and egress traffic by default`, podNamespaceHasDefaultDeny(netpols.NetworkPolicies()))
 	allChecks.RegisterNetworkPolicyCheck("NetworkPolicy targets Pod", `Makes sure that all NetworkPolicies targets at least one Pod`, networkPolicyTargetsPod(pods.Pods(), podspecers.PodSpeccers()))
+	allChecks.RegisterNetworkPolicyCheck("NetworkPolicy Allow All", `Makes sure that NetworkPolicies do not have rules that allow traffic from or to anywhere`, networkPolicyAllowAll)
+	allChecks.RegisterOptionalNetworkPolicyCheck("NetworkPolicy Peers Match", `Makes sure that the podSelector of all NetworkPolicy rules match at least one Pod. Namespaces that are not part of the input are not checked`, networkPolicyPeersMatch(pods.Pods(), podspecers.PodSpeccers(), namespaces.Namespaces(), netpols.NetworkPolicies()))
 }
 
 // podHasNetworkPolicy returns a function that tests that all pods have matching NetworkPolicies
@@ -30,37 +35,10 @@ func podHasNetworkPolicy(allNetpols []ks.NetworkPolicy) func(ks.PodSpecer) (scor
 				continue
 			}
 
-			if selector, err := metav1.LabelSelectorAsSelector(&netPol.Spec.PodSelector); err == nil {
-				if selector.Matches(internal.MapLabels(ps.GetPodTemplateSpec().Labels)) {
-
-					// Documentation of PolicyTypes
-					//
-					// List of rule types that the NetworkPolicy relates to.
-					// Valid options are "Ingress", "Egress", or "Ingress,Egress".
-					// If this field is not specified, it will default based on the existence of Ingress or Egress rules;
-					// policies that contain an Egress section are assumed to affect Egress, and all policies
-					// (whether or not they contain an Ingress section) are assumed to affect Ingress.
-					// If you want to write an egress-only policy, you must explicitly specify policyTypes [ "Egress" ].
-					// Likewise, if you want to write a policy that specifies that no egress is allowed,
-					// you must specify a policyTypes value that include "Egress" (since such a policy would not include
-					// an Egress section and would otherwise default to just [ "Ingress" ]).
-
-					if netPol.Spec.PolicyTypes == nil || len(netPol.Spec.PolicyTypes) == 0 {
-						hasMatchingIngressNetpol = true
-						if len(netPol.Spec.Egress) > 0 {
-							hasMatchingEgressNetpol = true
-						}
-					} else {
-						for _, policyType := range netPol.Spec.PolicyTypes {
-							if policyType == networkingv1.PolicyTypeIngress {
-								hasMatchingIngressNetpol = true
-							}
-							if policyType == networkingv1.PolicyTypeEgress {
-								hasMatchingEgressNetpol = true
-							}
-						}
-					}
-				}
+			if selectorMatches(&netPol.Spec.PodSelector, ps.GetPodTemplateSpec().Labels) {
+				ingress, egress := policyTypes(netPol)
+				hasMatchingIngressNetpol = hasMatchingIngressNetpol || ingress
+				hasMatchingEgressNetpol = hasMatchingEgressNetpol || egress
 			}
 		}
 
@@ -125,3 +103,101 @@ func networkPolicyTargetsPod(pods []ks.Pod, podspecers []ks.PodSpecer) func(netw
 		return
 	}
 }
+
+// podNamespaceHasDefaultDeny checks that the namespace of the pod has default deny policies for ingress and egress
+// Without a default deny policy, new workloads in the namespace are not isolated until a NetworkPolicy is created for them.
+func podNamespaceHasDefaultDeny(allNetpols []ks.NetworkPolicy) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		var denyIngress, denyEgress bool
+		for _, n := range allNetpols {
+			netpol := n.NetworkPolicy()
+			if netpol.Namespace != ps.GetPodTemplateSpec().Namespace {
+				continue
+			}
+			ingress, egress := defaultDeny(netpol)
+			denyIngress = denyIngress || ingress
+			denyEgress = denyEgress || egress
+		}
+
+		score.Grade = scorecard.GradeAllOK
+		if !denyIngress {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("", "The namespace has no default deny ingress NetworkPolicy",
+				"Add a NetworkPolicy with an empty podSelector, policyTypes Ingress, and no ingress rules. All ingress traffic to pods in the namespace is then denied, unless it is allowed by another NetworkPolicy.")
+		}
+		if !denyEgress {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment("", "The namespace has no default deny egress NetworkPolicy",
+				"Add a NetworkPolicy with an empty podSelector, policyTypes Egress, and no egress rules. All egress traffic from pods in the namespace is then denied, unless it is allowed by another NetworkPolicy.")
+		}
+		return
+	}
+}
+
+// networkPolicyAllowAll checks for rules that allow traffic from or to anywhere
+// A rule without peers, or with the ipBlock 0.0.0.0/0, allows traffic from all pods in the cluster and the internet.
+// A rule with an empty namespaceSelector and no podSelector allows traffic from all pods in the cluster.
+// A rule that is limited to specific ports is less severe, and is common for public services and DNS.
+func networkPolicyAllowAll(netpol networkingv1.NetworkPolicy) (score scorecard.TestScore, err error) {
+	score.Grade = scorecard.GradeAllOK
+
+	check := func(path, direction string, peers []networkingv1.NetworkPolicyPeer, ports []networkingv1.NetworkPolicyPort) {
+		if !isAllowAll(peers) {
+			return
+		}
+		if len(ports) > 0 {
+			score.LowerGrade(scorecard.GradeAlmostOK)
+			score.AddComment(path, fmt.Sprintf("The rule allows %s traffic on %s from or to %s", direction, describePorts(ports), describePeers(peers)),
+				"Make sure that the ports are intended to be reachable from anywhere, or limit the rule to specific pods, namespaces or IP ranges.")
+			return
+		}
+		score.Grade = scorecard.GradeWarning
+		score.AddComment(path, fmt.Sprintf("The rule allows all %s traffic from or to %s", direction, describePeers(peers)),
+			"The rule makes the policy ineffective. Limit the rule to specific ports, pods, namespaces or IP ranges.")
+	}
+
+	ingress, egress := policyTypes(netpol)
+	if ingress {
+		for i, rule := range netpol.Spec.Ingress {
+			check(fmt.Sprintf("ingress[%d]", i), "ingress", rule.From, rule.Ports)
+		}
+	}
+	if egress {
+		for i, rule := range netpol.Spec.Egress {
+			check(fmt.Sprintf("egress[%d]", i), "egress", rule.To, rule.Ports)
+		}
+	}
+
+	return
+}
+
+// networkPolicyPeersMatch checks that the selectors of the peers of all rules match at least one pod or namespace
+// A selector that does not match anything is usually caused by a typo, and makes the rule deny the traffic that it
+// is supposed to allow. Only the pods and namespaces that are part of the input are considered, selectors of other
+// namespaces (such as kube-system) are not checked.
+func networkPolicyPeersMatch(pods []ks.Pod, podspecers []ks.PodSpecer, namespaces []ks.Namespace, allNetpols []ks.NetworkPolicy) func(networkingv1.NetworkPolicy) (scorecard.TestScore, error) {
+	workloads := allWorkloads(pods, podspecers)
+	allNamespaces := knownNamespaces(namespaces, workloads, allNetpols)
+
+	return func(netpol networkingv1.NetworkPolicy) (score scorecard.TestScore, err error) {
+		score.Grade = scorecard.GradeAllOK
+
+		check := func(path string, peers []networkingv1.NetworkPolicyPeer) {
+			for i, peer := range peers {
+				if msg := peerMatchError(peer, netpol.Namespace, allNamespaces, workloads); msg != "" {
+					score.Grade = scorecard.GradeWarning
+					score.AddComment(fmt.Sprintf("%s[%d]", path, i), "The rule selects nothing", msg)
+				}
+			}
+		}
+
+		for i, rule := range netpol.Spec.Ingress {
+			check(fmt.Sprintf("ingress[%d].from", i), rule.From)
+		}
+		for i, rule := range netpol.Spec.Egress {
+			check(fmt.Sprintf("egress[%d].to", i), rule.To)
+		}
+
+		return
+	}
+}
@@ -3,6 +3,12 @@ package score
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/parser"
+	"github.com/zegl/kube-score/score/networkpolicy"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -82,3 +88,143 @@ func TestNetworkPolicyEmptyPodSelector(t *testing.T) {
 	testExpectedScore(t, "networkpolicy-targets-all-pods.yaml", "NetworkPolicy targets Pod", scorecard.GradeAllOK)
 	testExpectedScore(t, "networkpolicy-targets-all-pods.yaml", "Pod NetworkPolicy", scorecard.GradeAllOK)
 }
+
+func networkPolicyCoverageScores(t *testing.T, runConfig *config.RunConfiguration) map[string]map[string]scorecard.TestScore {
+	sc, err := testScore([]ks.NamedReader{testFile("networkpolicy-coverage.yaml")}, nil, runConfig)
+	assert.NoError(t, err)
+
+	res := make(map[string]map[string]scorecard.TestScore)
+	for _, obj := range sc {
+		key := obj.TypeMeta.Kind + "/" + obj.ObjectMeta.Name
+		res[key] = make(map[string]scorecard.TestScore)
+		for _, c := range obj.Checks {
+			res[key][c.Check.Name] = c
+		}
+	}
+	return res
+}
+
+func TestNetworkPolicyAllowAll(t *testing.T) {
+	t.Parallel()
+	scores := networkPolicyCoverageScores(t, nil)
+
+	assert.Equal(t, scorecard.GradeAllOK, scores["NetworkPolicy/default-deny"]["NetworkPolicy Allow All"].Grade)
+
+	api := scores["NetworkPolicy/api"]["NetworkPolicy Allow All"]
+	assert.Equal(t, scorecard.GradeAlmostOK, api.Grade)
+	assert.Len(t, api.Comments, 1)
+	assert.Equal(t, "egress[0]", api.Comments[0].Path)
+	assert.Equal(t, "The rule allows egress traffic on 53/UDP from or to anywhere", api.Comments[0].Summary)
+
+	frontend := scores["NetworkPolicy/frontend"]["NetworkPolicy Allow All"]
+	assert.Equal(t, scorecard.GradeWarning, frontend.Grade)
+	assert.Equal(t, "The rule allows all egress traffic from or to anywhere", frontend.Comments[0].Summary)
+}
+
+func TestNetworkPolicyAllowAllNamespaces(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "networkpolicy-allow-all-namespaces.yaml", "NetworkPolicy Allow All", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "ingress[0]", comments[0].Path)
+	assert.Equal(t, "The rule allows all ingress traffic from or to all pods in all namespaces", comments[0].Summary)
+}
+
+func TestNetworkPolicyPeersMatch(t *testing.T) {
+	t.Parallel()
+	sc, err := testScore([]ks.NamedReader{testFile("networkpolicy-peers-match.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"networkpolicy-peers-match": {}},
+	})
+	assert.NoError(t, err)
+
+	scores := make(map[string]scorecard.TestScore)
+	for _, obj := range sc {
+		for _, c := range obj.Checks {
+			if c.Check.Name == "NetworkPolicy Peers Match" {
+				scores[obj.ObjectMeta.Name] = c
+			}
+		}
+	}
+
+	api := scores["api"]
+	assert.Equal(t, scorecard.GradeWarning, api.Grade)
+	assert.Equal(t, []scorecard.TestScoreComment{
+		{
+			Path:        "ingress[0].from[0]",
+			Summary:     "The rule selects nothing",
+			Description: "The podSelector app=frontnd does not match any pod in the namespace of the policy",
+		},
+		{
+			Path:        "ingress[0].from[1]",
+			Summary:     "The rule selects nothing",
+			Description: "The podSelector app=missing does not match any pod in the namespaces matched by team=a",
+		},
+	}, api.Comments)
+
+	// kube-system is not part of the input, so the DNS rule can't be verified
+	assert.Equal(t, scorecard.GradeAllOK, scores["dns"].Grade)
+	assert.Empty(t, scores["dns"].Comments)
+}
+
+func TestNetworkPolicyPeersMatchIsOptional(t *testing.T) {
+	t.Parallel()
+	scores := networkPolicyCoverageScores(t, nil)
+	assert.True(t, scores["NetworkPolicy/api"]["NetworkPolicy Peers Match"].Skipped)
+}
+
+func TestPodNetworkPolicyDefaultDeny(t *testing.T) {
+	t.Parallel()
+	scores := networkPolicyCoverageScores(t, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-networkpolicy-default-deny": {}},
+	})
+
+	assert.Equal(t, scorecard.GradeAllOK, scores["Deployment/api"]["Pod NetworkPolicy Default Deny"].Grade)
+	worker := scores["Deployment/worker"]["Pod NetworkPolicy Default Deny"]
+	assert.Equal(t, scorecard.GradeWarning, worker.Grade)
+	assert.Len(t, worker.Comments, 2)
+}
+
+func TestNetworkPolicySummarize(t *testing.T) {
+	t.Parallel()
+	p, err := parser.New(nil)
+	assert.NoError(t, err)
+	parsed, err := p.ParseFiles([]ks.NamedReader{testFile("networkpolicy-coverage.yaml")})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []networkpolicy.NamespaceSummary{
+		{
+			Namespace:          "prod",
+			DefaultDenyIngress: true,
+			DefaultDenyEgress:  true,
+			Workloads: []networkpolicy.WorkloadSummary{
+				{
+					Kind:            "Deployment",
+					Name:            "api",
+					IngressIsolated: true,
+					EgressIsolated:  true,
+					Ingress: []networkpolicy.Exposure{
+						{Policy: "api", Ports: "8080/TCP", Peers: "pods app=frontend; all pods in namespaces team=a"},
+						{Policy: "api", Ports: "all ports", Peers: "all pods in namespaces team=missing"},
+					},
+					Egress: []networkpolicy.Exposure{
+						{Policy: "api", Ports: "53/UDP", Peers: "anywhere"},
+					},
+				},
+				{
+					Kind:            "Deployment",
+					Name:            "frontend",
+					IngressIsolated: true,
+					EgressIsolated:  true,
+					Egress: []networkpolicy.Exposure{
+						{Policy: "frontend", Ports: "all ports", Peers: "anywhere"},
+					},
+				},
+			},
+		},
+		{
+			Namespace: "staging",
+			Workloads: []networkpolicy.WorkloadSummary{
+				{Kind: "Deployment", Name: "worker"},
+			},
+		},
+	}, networkpolicy.Summarize(parsed, parsed, parsed))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -94,3 +95,49 @@ func TestDeploymentPodDisruptionBudgetV1NoMatchMatchInOtherNamespace(t *testing.
 	diff := cmp.Diff(expected, actual)
 	assert.Empty(t, diff)
 }
+
+func TestPodDisruptionBudgetFeasibilityMinAvailableBlocking(t *testing.T) {
+	t.Parallel()
+	actual := testExpectedScore(t, "poddisruptionbudget-feasibility-min-available-blocking.yaml", "PodDisruptionBudget Feasibility", scorecard.GradeCritical)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "The PodDisruptionBudget does not allow any pod to be evicted", actual[0].Summary)
+	assert.Contains(t, actual[0].Description, "With minAvailable 3 and 3 expected pods from Deployment app (3 replicas)")
+}
+
+func TestPodDisruptionBudgetFeasibilityMaxUnavailableZero(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "poddisruptionbudget-feasibility-max-unavailable-zero.yaml", "PodDisruptionBudget Feasibility", scorecard.GradeCritical)
+}
+
+func TestPodDisruptionBudgetFeasibilityInvalidValue(t *testing.T) {
+	t.Parallel()
+	actual := testExpectedScore(t, "poddisruptionbudget-feasibility-invalid.yaml", "PodDisruptionBudget Feasibility", scorecard.GradeCritical)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "The PodDisruptionBudget has an invalid maxUnavailable", actual[0].Summary)
+	assert.Contains(t, actual[0].Description, "invalid maxUnavailable: ")
+}
+
+func TestPodDisruptionBudgetFeasibilityHPAMinReplicas(t *testing.T) {
+	t.Parallel()
+	actual := testExpectedScore(t, "poddisruptionbudget-feasibility-hpa.yaml", "PodDisruptionBudget Feasibility", scorecard.GradeCritical)
+	assert.Len(t, actual, 1)
+	assert.Contains(t, actual[0].Description, "Deployment app (2 minReplicas)")
+}
+
+func TestPodDisruptionBudgetFeasibilityOK(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "poddisruptionbudget-feasibility-ok.yaml", "PodDisruptionBudget Feasibility", scorecard.GradeAllOK)
+}
+
+func TestPodDisruptionBudgetFeasibilityIneffective(t *testing.T) {
+	t.Parallel()
+	actual := testExpectedScore(t, "poddisruptionbudget-feasibility-ineffective.yaml", "PodDisruptionBudget Feasibility", scorecard.GradeWarning)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, "The PodDisruptionBudget allows all pods to be evicted", actual[0].Summary)
+}
+
+func TestPodDisruptionBudgetFeasibilityNoTarget(t *testing.T) {
+	t.Parallel()
+	skipped := wasSkipped(t, []ks.NamedReader{testFile("poddisruptionbudget-feasibility-no-target.yaml")}, nil, nil, "PodDisruptionBudget Feasibility")
+	assert.True(t, skipped)
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -96,3 +98,111 @@ func TestProbesMultipleContainersInit(t *testing.T) {
 	comments := testExpectedScore(t, "pod-probes-on-different-containers-init.yaml", "Pod Probes", scorecard.GradeAllOK)
 	assert.Len(t, comments, 0)
 }
+
+func TestProbesPodIdenticalGRPC(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "pod-probes-identical-grpc.yaml", "Pod Probes", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "Container has the same readiness and liveness probe", comments[0].Summary)
+}
+
+func TestProbesPodDifferentNamedPorts(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "pod-probes-different-named-ports.yaml", "Pod Probes", scorecard.GradeAllOK)
+}
+
+func TestProbeTiming(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-probes-validation.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-probe-timing": {}},
+	}, "Container Probe Timing", scorecard.GradeWarning)
+	assert.Equal(t, []scorecard.TestScoreComment{
+		{
+			Path:        "app",
+			Summary:     "The livenessProbe timeout is not shorter than the period",
+			Description: "timeoutSeconds (5) should be lower than periodSeconds (2), otherwise a slow probe will overlap with the next one",
+		},
+		{
+			Path:             "app",
+			Summary:          "The livenessProbe can restart the container before it has started",
+			Description:      "Without a startupProbe, the container is restarted if it has not started within 6 seconds (initialDelaySeconds + failureThreshold * periodSeconds). Add a startupProbe, or increase initialDelaySeconds to give the container at least 30 seconds to start.",
+			DocumentationURL: "https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/#define-startup-probes",
+		},
+	}, comments)
+}
+
+func TestProbeTimingFailureWindow(t *testing.T) {
+	t.Parallel()
+	summaries := getSummaries(t, []ks.NamedReader{testFile("pod-probes-validation.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-probe-timing": {}},
+		ProbePolicy:          config.ProbePolicy{MinFailureWindow: 10, MaxFailureWindow: 120},
+	}, "Container Probe Timing")
+	assert.Equal(t, []string{
+		"The livenessProbe timeout is not shorter than the period",
+		"The livenessProbe fails too quickly",
+		"The livenessProbe can restart the container before it has started",
+		"The livenessProbe fails too quickly",
+		"The startupProbe fails too slowly",
+	}, summaries)
+}
+
+func TestProbeTimingNoMinLivenessStartupWindow(t *testing.T) {
+	t.Parallel()
+	disabled := int32(0)
+	summaries := getSummaries(t, []ks.NamedReader{testFile("pod-probes-validation.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-probe-timing": {}},
+		ProbePolicy:          config.ProbePolicy{MinLivenessStartupWindow: &disabled},
+	}, "Container Probe Timing")
+	assert.Equal(t, []string{"The livenessProbe timeout is not shorter than the period"}, summaries)
+}
+
+func TestProbeTimingSidecar(t *testing.T) {
+	t.Parallel()
+	summaries := getSummaries(t, []ks.NamedReader{testFile("pod-probes-sidecar.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-probe-timing": {}},
+	}, "Container Probe Timing")
+	assert.Equal(t, []string{"The livenessProbe timeout is not shorter than the period"}, summaries)
+}
+
+func TestProbeNamedPorts(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "pod-probes-validation.yaml", "Container Probe Named Ports", scorecard.GradeCritical)
+	assert.Equal(t, []scorecard.TestScoreComment{
+		{
+			Path:        "app",
+			Summary:     `The livenessProbe uses the undeclared port "metrics"`,
+			Description: `The container does not have a port named "metrics", and the probe will always fail. Add the port to the ports of the container, or use a port number.`,
+		},
+	}, comments)
+}
+
+func TestProbeNamedPortsDeclared(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "pod-probes-different-named-ports.yaml", "Container Probe Named Ports", scorecard.GradeAllOK)
+}
+
+func TestProbeNamedPortsSidecar(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "pod-probes-sidecar.yaml", "Container Probe Named Ports", scorecard.GradeCritical)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "proxy", comments[0].Path)
+	assert.Equal(t, `The livenessProbe uses the undeclared port "admin"`, comments[0].Summary)
+	assert.Equal(t, `The startupProbe uses the undeclared port "admin"`, comments[1].Summary)
+}
+
+func TestExecProbeDistroless(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "pod-probes-validation.yaml", "Container Exec Probe Distroless", scorecard.GradeWarning)
+	assert.Equal(t, []scorecard.TestScoreComment{
+		{
+			Path:        "app",
+			Summary:     "The readinessProbe runs sh on a distroless image",
+			Description: "The image gcr.io/distroless/static-debian12:nonroot is unlikely to contain sh, and the probe will always fail. Use an httpGet, tcpSocket or grpc probe instead.",
+		},
+	}, comments)
+}
+
+func TestExecProbeDistrolessNotDistroless(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "pod-probes-identical-exec.yaml", "Container Exec Probe Distroless", scorecard.GradeAllOK)
+}
@@ -1,6 +1,7 @@
 package probes
 
 import (
+	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
 	"github.com/zegl/kube-score/score/internal"
@@ -8,8 +9,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-func Register(allChecks *checks.Checks, services ks.Services) {
+func Register(allChecks *checks.Checks, services ks.Services, policy config.ProbePolicy) {
 	allChecks.RegisterPodCheck("Pod Probes", `Makes sure that all Pods have safe probe configurations`, containerProbes(services.Services()))
+	allChecks.RegisterOptionalPodCheck("Container Probe Timing", `Makes sure that the probe timeouts are shorter than the periods, that the failure windows are within the bounds configured with --min-probe-failure-window and --max-probe-failure-window, and that a livenessProbe without a startupProbe gives the container enough time to start`, containerProbeTiming(policy))
+	allChecks.RegisterPodCheck("Container Probe Named Ports", `Makes sure that the named ports used by probes are declared by the container`, containerProbeNamedPorts)
+	allChecks.RegisterPodCheck("Container Exec Probe Distroless", `Makes sure that exec probes on distroless images don't depend on a shell or other tools that are not in the image`, containerExecProbeDistroless)
 }
 
 // containerProbes returns a function that checks if all probes are defined correctly in the Pod.
@@ -31,7 +35,7 @@ func containerProbes(allServices []ks.Service) func(ks.PodSpecer) (scorecard.Tes
 
 		hasReadinessProbe := false
 		hasLivenessProbe := false
-		probesAreIdentical := false
+		identicalProbes := false
 		isTargetedByService := false
 
 		for _, s := range allServices {
@@ -51,43 +55,13 @@ func containerProbes(allServices []ks.Service) func(ks.PodSpecer) (scorecard.Tes
 			}
 
 			if container.ReadinessProbe != nil && container.LivenessProbe != nil {
-
-				r := container.ReadinessProbe
-				l := container.LivenessProbe
-
-				if r.HTTPGet != nil && l.HTTPGet != nil {
-					if r.HTTPGet.Path == l.HTTPGet.Path &&
-						r.HTTPGet.Port.IntValue() == l.HTTPGet.Port.IntValue() {
-						probesAreIdentical = true
-					}
-				}
-
-				if r.TCPSocket != nil && l.TCPSocket != nil {
-					if r.TCPSocket.Port == l.TCPSocket.Port {
-						probesAreIdentical = true
-					}
-				}
-
-				if r.Exec != nil && l.Exec != nil {
-					if len(r.Exec.Command) == len(l.Exec.Command) {
-						hasDifferent := false
-						for i, v := range r.Exec.Command {
-							if l.Exec.Command[i] != v {
-								hasDifferent = true
-								break
-							}
-						}
-
-						if !hasDifferent {
-							probesAreIdentical = true
-						}
-					}
+				if probesAreIdentical(container.ReadinessProbe, container.LivenessProbe) {
+					identicalProbes = true
 				}
-
 			}
 		}
 
-		if hasLivenessProbe && hasReadinessProbe && probesAreIdentical {
+		if hasLivenessProbe && hasReadinessProbe && identicalProbes {
 			score.Grade = scorecard.GradeCritical
 			score.AddCommentWithURL(
 				"", "Container has the same readiness and liveness probe",
@@ -131,13 +105,44 @@ func containerProbes(allServices []ks.Service) func(ks.PodSpecer) (scorecard.Tes
 	}
 }
 
-func podIsTargetedByService(pod corev1.PodTemplateSpec, service corev1.Service) bool {
-	if pod.Namespace != service.Namespace {
-		return false
+// probesAreIdentical returns true if the two probes are checking the same thing, in the same way
+func probesAreIdentical(r, l *corev1.Probe) bool {
+	if r.HTTPGet != nil && l.HTTPGet != nil {
+		return r.HTTPGet.Path == l.HTTPGet.Path &&
+			r.HTTPGet.Port == l.HTTPGet.Port
+	}
+
+	if r.TCPSocket != nil && l.TCPSocket != nil {
+		return r.TCPSocket.Port == l.TCPSocket.Port
+	}
+
+	if r.GRPC != nil && l.GRPC != nil {
+		return r.GRPC.Port == l.GRPC.Port &&
+			grpcService(r.GRPC) == grpcService(l.GRPC)
 	}
 
-	return internal.LabelSelectorMatchesLabels(
-		service.Spec.Selector,
-		pod.GetObjectMeta().GetLabels(),
-	)
+	if r.Exec != nil && l.Exec != nil {
+		if len(r.Exec.Command) != len(l.Exec.Command) {
+			return false
+		}
+		for i, v := range r.Exec.Command {
+			if l.Exec.Command[i] != v {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func grpcService(g *corev1.GRPCAction) string {
+	if g.Service == nil {
+		return ""
+	}
+	return *g.Service
+}
+
+func podIsTargetedByService(pod corev1.PodTemplateSpec, service corev1.Service) bool {
+	return internal.PodIsTargetedByService(pod, service)
 }
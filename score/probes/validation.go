@@ -0,0 +1,184 @@
+package probes
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/scorecard"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// The default values of the probe fields, as set by the API server
+const (
+	defaultTimeoutSeconds   = 1
+	defaultPeriodSeconds    = 10
+	defaultFailureThreshold = 3
+
+	defaultMinLivenessStartupWindow = 30
+)
+
+type namedProbe struct {
+	name  string
+	probe *corev1.Probe
+}
+
+func containerProbeList(container corev1.Container) []namedProbe {
+	var res []namedProbe
+	for _, p := range []namedProbe{
+		{"livenessProbe", container.LivenessProbe},
+		{"readinessProbe", container.ReadinessProbe},
+		{"startupProbe", container.StartupProbe},
+	} {
+		if p.probe != nil {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+func valueOrDefault(v, def int32) int32 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// containerProbeTiming checks the timing configuration of all probes
+//
+// A probe where the timeout is not shorter than the period is flagged, as is a probe where
+// failureThreshold * periodSeconds is outside of the configured bounds. A livenessProbe without a startupProbe
+// is flagged if the container is restarted before it's given enough time to start.
+func containerProbeTiming(policy config.ProbePolicy) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	minStartupWindow := int32(defaultMinLivenessStartupWindow)
+	if policy.MinLivenessStartupWindow != nil {
+		minStartupWindow = *policy.MinLivenessStartupWindow
+	}
+
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		score.Grade = scorecard.GradeAllOK
+
+		for _, container := range internal.RunningContainers(ps.GetPodTemplateSpec().Spec) {
+			for _, p := range containerProbeList(container) {
+				timeout := valueOrDefault(p.probe.TimeoutSeconds, defaultTimeoutSeconds)
+				period := valueOrDefault(p.probe.PeriodSeconds, defaultPeriodSeconds)
+				failureThreshold := valueOrDefault(p.probe.FailureThreshold, defaultFailureThreshold)
+
+				if timeout >= period {
+					score.LowerGrade(scorecard.GradeWarning)
+					score.AddComment(container.Name, fmt.Sprintf("The %s timeout is not shorter than the period", p.name),
+						fmt.Sprintf("timeoutSeconds (%d) should be lower than periodSeconds (%d), otherwise a slow probe will overlap with the next one", timeout, period))
+				}
+
+				window := failureThreshold * period
+				if policy.MinFailureWindow > 0 && window < policy.MinFailureWindow {
+					score.LowerGrade(scorecard.GradeWarning)
+					score.AddComment(container.Name, fmt.Sprintf("The %s fails too quickly", p.name),
+						fmt.Sprintf("failureThreshold * periodSeconds is %d seconds, the minimum is %d seconds. Short hiccups will cause the probe to fail.", window, policy.MinFailureWindow))
+				}
+				if policy.MaxFailureWindow > 0 && window > policy.MaxFailureWindow {
+					score.LowerGrade(scorecard.GradeWarning)
+					score.AddComment(container.Name, fmt.Sprintf("The %s fails too slowly", p.name),
+						fmt.Sprintf("failureThreshold * periodSeconds is %d seconds, the maximum is %d seconds. It takes too long to detect a failing container.", window, policy.MaxFailureWindow))
+				}
+			}
+
+			if container.LivenessProbe != nil && container.StartupProbe == nil {
+				l := container.LivenessProbe
+				window := l.InitialDelaySeconds + valueOrDefault(l.FailureThreshold, defaultFailureThreshold)*valueOrDefault(l.PeriodSeconds, defaultPeriodSeconds)
+				if minStartupWindow > 0 && window < minStartupWindow {
+					score.LowerGrade(scorecard.GradeWarning)
+					score.AddCommentWithURL(container.Name, "The livenessProbe can restart the container before it has started",
+						fmt.Sprintf("Without a startupProbe, the container is restarted if it has not started within %d seconds (initialDelaySeconds + failureThreshold * periodSeconds). "+
+							"Add a startupProbe, or increase initialDelaySeconds to give the container at least %d seconds to start.", window, minStartupWindow),
+						"https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/#define-startup-probes")
+				}
+			}
+		}
+
+		return
+	}
+}
+
+// containerProbeNamedPorts checks that all named ports used by probes are declared by the container
+// The kubelet only resolves named ports from the ports of the container that the probe belongs to.
+func containerProbeNamedPorts(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range internal.RunningContainers(ps.GetPodTemplateSpec().Spec) {
+		declared := make(map[string]struct{})
+		for _, port := range container.Ports {
+			if port.Name != "" {
+				declared[port.Name] = struct{}{}
+			}
+		}
+
+		for _, p := range containerProbeList(container) {
+			var port intstr.IntOrString
+			switch {
+			case p.probe.HTTPGet != nil:
+				port = p.probe.HTTPGet.Port
+			case p.probe.TCPSocket != nil:
+				port = p.probe.TCPSocket.Port
+			default:
+				continue
+			}
+
+			if port.Type != intstr.String {
+				continue
+			}
+			if _, ok := declared[port.StrVal]; !ok {
+				score.LowerGrade(scorecard.GradeCritical)
+				score.AddComment(container.Name, fmt.Sprintf("The %s uses the undeclared port %q", p.name, port.StrVal),
+					fmt.Sprintf("The container does not have a port named %q, and the probe will always fail. Add the port to the ports of the container, or use a port number.", port.StrVal))
+			}
+		}
+	}
+
+	return
+}
+
+// shells and tools that are commonly used in exec probes, but that are not available in distroless images
+var notInDistroless = map[string]struct{}{
+	"sh": {}, "bash": {}, "ash": {}, "dash": {}, "zsh": {},
+	"curl": {}, "wget": {}, "cat": {}, "test": {}, "ls": {}, "grep": {}, "pgrep": {}, "ps": {}, "nc": {},
+}
+
+// isDistrolessImage returns true if the image is a distroless-style image, without a shell or package manager
+func isDistrolessImage(image string) bool {
+	ref := internal.ParseImageReference(image)
+	if ref.Registry == "cgr.dev" {
+		return true
+	}
+	return strings.Contains(ref.Repository, "distroless")
+}
+
+// containerExecProbeDistroless checks for exec probes that use a shell or common tools on distroless images
+func containerExecProbeDistroless(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range internal.RunningContainers(ps.GetPodTemplateSpec().Spec) {
+		if !isDistrolessImage(container.Image) {
+			continue
+		}
+
+		for _, p := range containerProbeList(container) {
+			if p.probe.Exec == nil || len(p.probe.Exec.Command) == 0 {
+				continue
+			}
+
+			command := path.Base(p.probe.Exec.Command[0])
+			if _, ok := notInDistroless[command]; ok {
+				score.LowerGrade(scorecard.GradeWarning)
+				score.AddComment(container.Name, fmt.Sprintf("The %s runs %s on a distroless image", p.name, command),
+					fmt.Sprintf("The image %s is unlikely to contain %s, and the probe will always fail. Use an httpGet, tcpSocket or grpc probe instead.", container.Image, command))
+			}
+		}
+	}
+
+	return
+}
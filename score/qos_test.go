@@ -0,0 +1,71 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func qosScores(t *testing.T, runConfig *config.RunConfiguration) map[string]*scorecard.ScoredObject {
+	sc, err := testScore([]ks.NamedReader{testFile("deployment-min-qos.yaml")}, nil, runConfig)
+	assert.NoError(t, err)
+	res := make(map[string]*scorecard.ScoredObject)
+	for _, o := range sc {
+		res[o.ObjectMeta.Name] = o
+	}
+	return res
+}
+
+func qosCheck(o *scorecard.ScoredObject) scorecard.TestScore {
+	for _, c := range o.Checks {
+		if c.Check.Name == "Pod QoS Class" {
+			return c
+		}
+	}
+	return scorecard.TestScore{}
+}
+
+func TestPodQOSClassComputed(t *testing.T) {
+	t.Parallel()
+	objects := qosScores(t, nil)
+	assert.Equal(t, corev1.PodQOSBurstable, objects["burstable"].QOSClass)
+	assert.Equal(t, corev1.PodQOSGuaranteed, objects["guaranteed"].QOSClass)
+	assert.Equal(t, corev1.PodQOSBestEffort, objects["best-effort"].QOSClass)
+}
+
+func TestPodQOSClassLabel(t *testing.T) {
+	t.Parallel()
+	objects := qosScores(t, nil)
+
+	burstable := qosCheck(objects["burstable"])
+	assert.Equal(t, scorecard.GradeCritical, burstable.Grade)
+	assert.Equal(t, []scorecard.TestScoreComment{{
+		Summary:          "The pod has the QoS class Burstable, the minimum is Guaranteed",
+		Description:      "The minimum is set by the kube-score/min-qos label. Set CPU and memory requests and limits on all containers, including init containers, with the requests equal to the limits.",
+		DocumentationURL: "https://kubernetes.io/docs/concepts/workloads/pods/pod-qos/",
+	}}, burstable.Comments)
+
+	assert.Equal(t, scorecard.GradeAllOK, qosCheck(objects["guaranteed"]).Grade)
+	assert.True(t, qosCheck(objects["best-effort"]).Skipped)
+
+	invalid := qosCheck(objects["invalid"])
+	assert.Equal(t, scorecard.GradeWarning, invalid.Grade)
+	assert.Equal(t, `Unknown QoS class "Gold"`, invalid.Comments[0].Summary)
+}
+
+func TestPodQOSClassConfig(t *testing.T) {
+	t.Parallel()
+	objects := qosScores(t, &config.RunConfiguration{MinQOSClass: "Burstable"})
+
+	// The label takes precedence over the configured minimum
+	assert.Equal(t, scorecard.GradeCritical, qosCheck(objects["burstable"]).Grade)
+
+	bestEffort := qosCheck(objects["best-effort"])
+	assert.Equal(t, scorecard.GradeCritical, bestEffort.Grade)
+	assert.Equal(t, "The pod has the QoS class BestEffort, the minimum is Burstable", bestEffort.Comments[0].Summary)
+	assert.Equal(t, "The minimum is set by the configuration. Set a CPU or memory request or limit on at least one container.", bestEffort.Comments[0].Description)
+}
@@ -0,0 +1,142 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestContainerResourceUnits(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-resources-units.yaml")}, nil, &config.RunConfiguration{
+		ResourcePolicy: config.ResourcePolicy{
+			CPUUnitThreshold:    resource.MustParse("100"),
+			MemoryUnitThreshold: resource.MustParse("1Mi"),
+		},
+	}, "Container Resource Units", scorecard.GradeCritical)
+	assert.Equal(t, []scorecard.TestScoreComment{
+		{
+			Path:        "millibytes",
+			Summary:     "resources.requests.cpu is set to 1k cores",
+			Description: "CPU quantities without a suffix are in cores. Use the \"m\" suffix to set the value in millicores, for example 1000m.",
+		},
+		{
+			Path:        "millibytes",
+			Summary:     "resources.limits.cpu is set to 1k cores",
+			Description: "CPU quantities without a suffix are in cores. Use the \"m\" suffix to set the value in millicores, for example 1000m.",
+		},
+		{
+			Path:        "millibytes",
+			Summary:     "resources.requests.memory is set to 512m millibytes",
+			Description: "The \"m\" suffix means milli, and not mega. Use Mi or M to set the value in mebibytes or megabytes, for example 512Mi.",
+		},
+		{
+			Path:        "bytes",
+			Summary:     "resources.limits.memory is set to 256 bytes",
+			Description: "Memory quantities without a suffix are in bytes. Use Mi or Gi to set the value in mebibytes or gibibytes, for example 512Mi.",
+		},
+		{
+			Path:        "fraction",
+			Summary:     "resources.requests.memory is not a whole number of bytes",
+			Description: "Memory quantities are rounded up to a whole number of bytes. Use a smaller unit to set an exact value, for example 1126Ki instead of 1.1Ki.",
+		},
+	}, comments)
+}
+
+func TestContainerResourceUnitsNoThresholds(t *testing.T) {
+	t.Parallel()
+	summaries := getSummaries(t, []ks.NamedReader{testFile("pod-resources-units.yaml")}, nil, &config.RunConfiguration{}, "Container Resource Units")
+	assert.Equal(t, []string{
+		"resources.requests.memory is set to 512m millibytes",
+		"resources.requests.memory is not a whole number of bytes",
+	}, summaries)
+}
+
+func TestContainerResourceUnitsAllGood(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "pod-resources-sized.yaml", "Container Resource Units", scorecard.GradeAllOK)
+}
+
+func TestContainerResourceLimitRequestRatio(t *testing.T) {
+	t.Parallel()
+	summaries := getSummaries(t, []ks.NamedReader{testFile("pod-resources-sized.yaml")}, nil, &config.RunConfiguration{
+		ResourcePolicy: config.ResourcePolicy{MaxLimitRequestRatio: 4},
+	}, "Container Resource Limit Request Ratio")
+	assert.Equal(t, []string{
+		"The cpu limit is 20.0 times the request",
+		"The memory limit is 64.0 times the request",
+	}, summaries)
+}
+
+func TestContainerResourceLimitRequestRatioSkipped(t *testing.T) {
+	t.Parallel()
+	assert.True(t, wasSkipped(t, []ks.NamedReader{testFile("pod-resources-sized.yaml")}, nil, nil, "Container Resource Limit Request Ratio"))
+}
+
+func TestContainerResourceBounds(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-resources-sized.yaml")}, nil, &config.RunConfiguration{
+		ResourcePolicy: config.ResourcePolicy{
+			MinContainerCPU:    resource.MustParse("10m"),
+			MaxContainerMemory: resource.MustParse("4Gi"),
+		},
+	}, "Container Resource Bounds", scorecard.GradeWarning)
+	assert.Equal(t, []scorecard.TestScoreComment{
+		{
+			Path:        "app",
+			Summary:     "The memory limit is above the maximum",
+			Description: "The limit (8Gi) is higher than the configured maximum of 4Gi",
+		},
+		{
+			Path:        "sidecar",
+			Summary:     "The cpu request is below the minimum",
+			Description: "The request (5m) is lower than the configured minimum of 10m",
+		},
+	}, comments)
+}
+
+func TestContainerResourceBoundsSkipped(t *testing.T) {
+	t.Parallel()
+	assert.True(t, wasSkipped(t, []ks.NamedReader{testFile("pod-resources-sized.yaml")}, nil, nil, "Container Resource Bounds"))
+}
+
+func TestPodResourcesFitNode(t *testing.T) {
+	t.Parallel()
+
+	// The init container requests 3 CPUs, which is more than the containers together
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-resources-sized.yaml")}, nil, &config.RunConfiguration{
+		ResourcePolicy: config.ResourcePolicy{
+			NodeCPU:    resource.MustParse("2"),
+			NodeMemory: resource.MustParse("4Gi"),
+		},
+	}, "Pod Resources Fit Node", scorecard.GradeCritical)
+	assert.Equal(t, []scorecard.TestScoreComment{
+		{
+			Summary:     "The pod requests more cpu than a node has",
+			Description: "The pod requests 3 in total, but a node only has 2. The pod can never be scheduled.",
+		},
+		{
+			Summary:     "The pod has a higher memory limit than a node has",
+			Description: "The pod has a limit of 8208Mi in total, but a node only has 4Gi. The limit can never be reached.",
+		},
+	}, comments)
+}
+
+func TestPodResourcesFitNodeAllGood(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-resources-sized.yaml")}, nil, &config.RunConfiguration{
+		ResourcePolicy: config.ResourcePolicy{
+			NodeCPU:    resource.MustParse("4"),
+			NodeMemory: resource.MustParse("16Gi"),
+		},
+	}, "Pod Resources Fit Node", scorecard.GradeAllOK)
+}
+
+func TestPodResourcesFitNodeSkipped(t *testing.T) {
+	t.Parallel()
+	assert.True(t, wasSkipped(t, []ks.NamedReader{testFile("pod-resources-sized.yaml")}, nil, nil, "Pod Resources Fit Node"))
+}
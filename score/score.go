@@ -2,6 +2,7 @@ package score
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
@@ -13,10 +14,13 @@ import (
 	"github.com/zegl/kube-score/score/disruptionbudget"
 	"github.com/zegl/kube-score/score/hpa"
 	"github.com/zegl/kube-score/score/ingress"
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/score/lifecycle"
 	"github.com/zegl/kube-score/score/meta"
 	"github.com/zegl/kube-score/score/networkpolicy"
 	"github.com/zegl/kube-score/score/podtopologyspreadconstraints"
 	"github.com/zegl/kube-score/score/probes"
+	"github.com/zegl/kube-score/score/secrets"
 	"github.com/zegl/kube-score/score/security"
 	"github.com/zegl/kube-score/score/service"
 	"github.com/zegl/kube-score/score/stable"
@@ -26,21 +30,27 @@ import (
 )
 
 func RegisterAllChecks(allObjects ks.AllTypes, checksConfig *checks.Config, runConfig *config.RunConfiguration) *checks.Checks {
+	if runConfig == nil {
+		runConfig = &config.RunConfiguration{}
+	}
+
 	allChecks := checks.New(checksConfig)
 
 	deployment.Register(allChecks, allObjects)
-	ingress.Register(allChecks, allObjects)
-	cronjob.Register(allChecks)
-	container.Register(allChecks, runConfig.IgnoreContainerCpuLimitRequirement, runConfig.IgnoreContainerMemoryLimitRequirement)
+	ingress.Register(allChecks, allObjects, allObjects)
+	cronjob.Register(allChecks, runConfig.KubernetesVersion, runConfig.CronJobPolicy)
+	container.Register(allChecks, runConfig.IgnoreContainerCpuLimitRequirement, runConfig.IgnoreContainerMemoryLimitRequirement, runConfig.ImagePolicy, runConfig.ResourcePolicy, runConfig.MinQOSClass)
 	disruptionbudget.Register(allChecks, allObjects)
-	networkpolicy.Register(allChecks, allObjects, allObjects, allObjects)
-	probes.Register(allChecks, allObjects)
-	security.Register(allChecks)
+	networkpolicy.Register(allChecks, allObjects, allObjects, allObjects, allObjects)
+	probes.Register(allChecks, allObjects, runConfig.ProbePolicy)
+	lifecycle.Register(allChecks, allObjects, runConfig.ShutdownPolicy)
+	secrets.Register(allChecks, runConfig.SecretPolicy)
+	security.Register(allChecks, runConfig.AllowedCapabilities, runConfig.AllowedHostPaths, runConfig.AllowedHostNamespaceKinds)
 	service.Register(allChecks, allObjects, allObjects)
 	stable.Register(runConfig.KubernetesVersion, allChecks)
 	apps.Register(allChecks, allObjects.HorizontalPodAutoscalers(), allObjects.Services())
 	meta.Register(allChecks)
-	hpa.Register(allChecks, allObjects.Metas())
+	hpa.Register(allChecks, allObjects, runConfig.AutoscalingPolicy)
 	podtopologyspreadconstraints.Register(allChecks)
 
 	return allChecks
@@ -68,6 +78,26 @@ func (p *podSpeccer) FileLocation() ks.FileLocation {
 	return ks.FileLocation{}
 }
 
+// ObjectError is returned by Score when a check fails on an object
+// The errors are caused by invalid values in the object, such as a maxUnavailable that is not a number or a percentage.
+type ObjectError struct {
+	Object string
+	Check  ks.Check
+	Err    error
+}
+
+func (e *ObjectError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Object, e.Check.ID, e.Err)
+}
+
+func (e *ObjectError) Unwrap() error {
+	return e.Err
+}
+
+func objectError(o *scorecard.ScoredObject, check ks.Check, err error) error {
+	return &ObjectError{Object: o.HumanFriendlyRef(), Check: check, Err: err}
+}
+
 // Score runs a pre-configured list of tests against the files defined in the configuration, and returns a scorecard.
 // Additional configuration and tuning parameters can be provided via the config.
 func Score(allObjects ks.AllTypes, allChecks *checks.Checks, cnf *config.RunConfiguration) (*scorecard.Scorecard, error) {
@@ -90,7 +120,7 @@ func Score(allObjects ks.AllTypes, allChecks *checks.Checks, cnf *config.RunConf
 		for _, test := range allChecks.Ingresses() {
 			fn, err := test.Fn(ingress)
 			if err != nil {
-				return nil, err
+				return nil, objectError(o, test.Check, err)
 			}
 			o.Add(fn, test.Check, ingress, ingress.GetObjectMeta().Annotations)
 		}
@@ -101,7 +131,7 @@ func Score(allObjects ks.AllTypes, allChecks *checks.Checks, cnf *config.RunConf
 		for _, test := range allChecks.Metas() {
 			fn, err := test.Fn(meta)
 			if err != nil {
-				return nil, err
+				return nil, objectError(o, test.Check, err)
 			}
 			o.Add(fn, test.Check, meta, meta.ObjectMeta.Annotations)
 		}
@@ -109,6 +139,7 @@ func Score(allObjects ks.AllTypes, allChecks *checks.Checks, cnf *config.RunConf
 
 	for _, pod := range allObjects.Pods() {
 		o := newObject(pod.Pod().TypeMeta, pod.Pod().ObjectMeta)
+		o.QOSClass = internal.PodQOSClass(pod.Pod().Spec)
 		for _, test := range allChecks.Pods() {
 
 			podTemplateSpec := corev1.PodTemplateSpec{
@@ -127,6 +158,7 @@ func Score(allObjects ks.AllTypes, allChecks *checks.Checks, cnf *config.RunConf
 
 	for _, podspecer := range allObjects.PodSpeccers() {
 		o := newObject(podspecer.GetTypeMeta(), podspecer.GetObjectMeta())
+		o.QOSClass = internal.PodQOSClass(podspecer.GetPodTemplateSpec().Spec)
 		for _, test := range allChecks.Pods() {
 			score, _ := test.Fn(podspecer)
 			o.Add(score, test.Check, podspecer,
@@ -141,29 +173,51 @@ func Score(allObjects ks.AllTypes, allChecks *checks.Checks, cnf *config.RunConf
 		for _, test := range allChecks.Services() {
 			fn, err := test.Fn(service.Service())
 			if err != nil {
-				return nil, err
+				return nil, objectError(o, test.Check, err)
 			}
 			o.Add(fn, test.Check, service, service.Service().Annotations)
 		}
 	}
 
+	for _, configMap := range allObjects.ConfigMaps() {
+		o := newObject(configMap.ConfigMap().TypeMeta, configMap.ConfigMap().ObjectMeta)
+		for _, test := range allChecks.ConfigMaps() {
+			fn, err := test.Fn(configMap.ConfigMap())
+			if err != nil {
+				return nil, objectError(o, test.Check, err)
+			}
+			o.Add(fn, test.Check, configMap, configMap.ConfigMap().Annotations)
+		}
+	}
+
 	for _, statefulset := range allObjects.StatefulSets() {
 		o := newObject(statefulset.StatefulSet().TypeMeta, statefulset.StatefulSet().ObjectMeta)
 		for _, test := range allChecks.StatefulSets() {
 			fn, err := test.Fn(statefulset.StatefulSet())
 			if err != nil {
-				return nil, err
+				return nil, objectError(o, test.Check, err)
 			}
 			o.Add(fn, test.Check, statefulset, statefulset.StatefulSet().ObjectMeta.Annotations)
 		}
 	}
 
+	for _, daemonset := range allObjects.DaemonSets() {
+		o := newObject(daemonset.DaemonSet().TypeMeta, daemonset.DaemonSet().ObjectMeta)
+		for _, test := range allChecks.DaemonSets() {
+			fn, err := test.Fn(daemonset.DaemonSet())
+			if err != nil {
+				return nil, objectError(o, test.Check, err)
+			}
+			o.Add(fn, test.Check, daemonset, daemonset.DaemonSet().ObjectMeta.Annotations)
+		}
+	}
+
 	for _, deployment := range allObjects.Deployments() {
 		o := newObject(deployment.Deployment().TypeMeta, deployment.Deployment().ObjectMeta)
 		for _, test := range allChecks.Deployments() {
 			res, err := test.Fn(deployment.Deployment())
 			if err != nil {
-				return nil, err
+				return nil, objectError(o, test.Check, err)
 			}
 			o.Add(res, test.Check, deployment, deployment.Deployment().ObjectMeta.Annotations)
 		}
@@ -174,18 +228,29 @@ func Score(allObjects ks.AllTypes, allChecks *checks.Checks, cnf *config.RunConf
 		for _, test := range allChecks.NetworkPolicies() {
 			fn, err := test.Fn(netpol.NetworkPolicy())
 			if err != nil {
-				return nil, err
+				return nil, objectError(o, test.Check, err)
 			}
 			o.Add(fn, test.Check, netpol, netpol.NetworkPolicy().ObjectMeta.Annotations)
 		}
 	}
 
+	for _, job := range allObjects.Jobs() {
+		o := newObject(job.Job().TypeMeta, job.Job().ObjectMeta)
+		for _, test := range allChecks.Jobs() {
+			fn, err := test.Fn(job.Job())
+			if err != nil {
+				return nil, objectError(o, test.Check, err)
+			}
+			o.Add(fn, test.Check, job, job.Job().ObjectMeta.Annotations)
+		}
+	}
+
 	for _, cjob := range allObjects.CronJobs() {
 		o := newObject(cjob.GetTypeMeta(), cjob.GetObjectMeta())
 		for _, test := range allChecks.CronJobs() {
 			fn, err := test.Fn(cjob)
 			if err != nil {
-				return nil, err
+				return nil, objectError(o, test.Check, err)
 			}
 			o.Add(fn, test.Check, cjob, cjob.GetObjectMeta().Annotations)
 		}
@@ -196,7 +261,7 @@ func Score(allObjects ks.AllTypes, allChecks *checks.Checks, cnf *config.RunConf
 		for _, test := range allChecks.HorizontalPodAutoscalers() {
 			fn, err := test.Fn(hpa)
 			if err != nil {
-				return nil, err
+				return nil, objectError(o, test.Check, err)
 			}
 			o.Add(fn, test.Check, hpa, hpa.GetObjectMeta().Annotations)
 		}
@@ -207,7 +272,7 @@ func Score(allObjects ks.AllTypes, allChecks *checks.Checks, cnf *config.RunConf
 		for _, test := range allChecks.PodDisruptionBudgets() {
 			fn, err := test.Fn(pdb)
 			if err != nil {
-				return nil, err
+				return nil, objectError(o, test.Check, err)
 			}
 			o.Add(fn, test.Check, pdb, pdb.GetObjectMeta().Annotations)
 		}
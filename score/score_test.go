@@ -306,6 +306,9 @@ func TestAnnotationIgnore(t *testing.T) {
 		for _, c := range o.Checks {
 			if c.Check.ID == "service-type" {
 				assert.True(t, c.Skipped)
+				assert.True(t, c.Ignored)
+				assert.Equal(t, scorecard.GradeWarning, c.Grade)
+				assert.Len(t, c.IgnoredComments, 1)
 				tested = true
 			}
 		}
@@ -328,6 +331,7 @@ func TestAnnotationIgnoreDisabled(t *testing.T) {
 		for _, c := range o.Checks {
 			if c.Check.ID == "service-type" {
 				assert.False(t, c.Skipped)
+				assert.False(t, c.Ignored)
 				assert.Equal(t, scorecard.GradeWarning, c.Grade)
 				tested = true
 			}
@@ -0,0 +1,122 @@
+package secrets
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+const (
+	highEntropyRuleName     = "high-entropy-string"
+	defaultEntropyThreshold = 4.0
+	minHighEntropyLength    = 20
+)
+
+type rule struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// defaultRules are the built in rules for known credential formats
+var defaultRules = []rule{
+	{"aws-access-key-id", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"aws-secret-access-key", regexp.MustCompile(`(?i)aws.{0,20}(secret|key).{0,20}[=:\s"']+[A-Za-z0-9/+]{40}\b`)},
+	{"private-key", regexp.MustCompile(`-----BEGIN ([A-Z0-9]+ )*PRIVATE KEY( BLOCK)?-----`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{"password-assignment", regexp.MustCompile(`(?i)\b[A-Z0-9_.-]*(password|passwd|pwd|secret|token|api_?key)\s*[=:]\s*["']?[^\s"'$]{4,}`)},
+}
+
+var highEntropyToken = regexp.MustCompile(`[A-Za-z0-9+/=_-]{20,}`)
+
+// ruleSet is a compiled set of rules used to detect secret material
+type ruleSet struct {
+	rules            []rule
+	entropyThreshold float64
+}
+
+// newRuleSet creates a ruleSet from the default rules and the additional rules on the format "name=regexp"
+// Rules in disabled are removed, this includes the "high-entropy-string" rule.
+func newRuleSet(additional []string, disabled map[string]struct{}, entropyThreshold float64) (*ruleSet, error) {
+	rs := &ruleSet{entropyThreshold: entropyThreshold}
+	if rs.entropyThreshold == 0 {
+		rs.entropyThreshold = defaultEntropyThreshold
+	}
+	if _, ok := disabled[highEntropyRuleName]; ok {
+		rs.entropyThreshold = -1
+	}
+
+	for _, r := range defaultRules {
+		if _, ok := disabled[r.name]; !ok {
+			rs.rules = append(rs.rules, r)
+		}
+	}
+
+	for _, a := range additional {
+		name, expr, ok := strings.Cut(a, "=")
+		if !ok || name == "" || expr == "" {
+			return nil, fmt.Errorf("invalid secret rule %q, expected format name=regexp", a)
+		}
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret rule %q: %w", name, err)
+		}
+		if _, ok := disabled[name]; !ok {
+			rs.rules = append(rs.rules, rule{name: name, pattern: pattern})
+		}
+	}
+
+	return rs, nil
+}
+
+type finding struct {
+	rule  string
+	match string
+}
+
+// scan returns the first finding in value, a finding of a known credential format is preferred over a high entropy string
+func (rs *ruleSet) scan(value string) (finding, bool) {
+	for _, r := range rs.rules {
+		if m := r.pattern.FindString(value); m != "" {
+			return finding{rule: r.name, match: m}, true
+		}
+	}
+
+	if rs.entropyThreshold > 0 {
+		for _, token := range highEntropyToken.FindAllString(value, -1) {
+			if len(token) < minHighEntropyLength || !hasLettersAndDigits(token) {
+				continue
+			}
+			if shannonEntropy(token) >= rs.entropyThreshold {
+				return finding{rule: highEntropyRuleName, match: token}, true
+			}
+		}
+	}
+
+	return finding{}, false
+}
+
+func hasLettersAndDigits(s string) bool {
+	return strings.ContainsAny(s, "0123456789") &&
+		strings.ContainsAny(strings.ToLower(s), "abcdefghijklmnopqrstuvwxyz")
+}
+
+// shannonEntropy returns the entropy of s in bits per character
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	var entropy float64
+	length := float64(len(s))
+	for _, c := range counts {
+		p := float64(c) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// redact replaces a secret with its length, so that the output does not leak any part of it
+func redact(secret string) string {
+	return fmt.Sprintf("<redacted, %d characters>", len(secret))
+}
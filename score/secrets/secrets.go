@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/scorecard"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Register(allChecks *checks.Checks, policy config.SecretPolicy) {
+	rs, err := newRuleSet(policy.Rules, policy.DisabledRules, policy.EntropyThreshold)
+
+	allChecks.RegisterOptionalPodCheck("Container Secret Material", `Makes sure that no credentials or other secrets are set in environment variables, command, or args. Use a secretKeyRef instead`, containerSecretMaterial(rs, err))
+	allChecks.RegisterOptionalConfigMapCheck("ConfigMap Secret Material", `Makes sure that no credentials or other secrets are stored in ConfigMaps. Use a Secret instead`, configMapSecretMaterial(rs, err))
+}
+
+// Validate returns an error if the additional rules in the policy are invalid
+func Validate(policy config.SecretPolicy) error {
+	_, err := newRuleSet(policy.Rules, policy.DisabledRules, policy.EntropyThreshold)
+	return err
+}
+
+// gradeFor returns the grade of a finding, matches of known credential formats are critical
+func gradeFor(f finding) scorecard.Grade {
+	if f.rule == highEntropyRuleName {
+		return scorecard.GradeWarning
+	}
+	return scorecard.GradeCritical
+}
+
+// containerSecretMaterial checks the env values, command, and args of all containers for secret material
+// The secrets are redacted in the comments.
+func containerSecretMaterial(rs *ruleSet, rsErr error) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		if rsErr != nil {
+			score.Grade = scorecard.GradeCritical
+			score.AddComment("", "Invalid secret detection rule", rsErr.Error())
+			return score, rsErr
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		for _, container := range internal.AllContainers(ps.GetPodTemplateSpec().Spec) {
+			for _, env := range container.Env {
+				// Include the name in the scanned value, to detect assignments such as "DB_PASSWORD=..."
+				if f, ok := rs.scan(env.Name + "=" + env.Value); ok && env.Value != "" {
+					score.LowerGrade(gradeFor(f))
+					score.AddComment(container.Name,
+						fmt.Sprintf("Environment variable %s contains a possible secret (%s)", env.Name, f.rule),
+						fmt.Sprintf("The value %s looks like a secret. Store the value in a Secret and reference it with env.valueFrom.secretKeyRef", redact(env.Value)))
+				}
+			}
+
+			scanArgs := func(field string, args []string) {
+				for i, arg := range args {
+					if f, ok := rs.scan(arg); ok {
+						score.LowerGrade(gradeFor(f))
+						score.AddComment(container.Name,
+							fmt.Sprintf("%s[%d] contains a possible secret (%s)", field, i, f.rule),
+							fmt.Sprintf("The value %s looks like a secret. Store the value in a Secret and pass it to the container as an environment variable, or mount it as a file", redact(f.match)))
+					}
+				}
+			}
+			scanArgs("command", container.Command)
+			scanArgs("args", container.Args)
+		}
+
+		return
+	}
+}
+
+// configMapSecretMaterial checks the data of a ConfigMap for secret material
+// The secrets are redacted in the comments.
+func configMapSecretMaterial(rs *ruleSet, rsErr error) func(corev1.ConfigMap) (scorecard.TestScore, error) {
+	return func(cm corev1.ConfigMap) (score scorecard.TestScore, err error) {
+		if rsErr != nil {
+			score.Grade = scorecard.GradeCritical
+			score.AddComment("", "Invalid secret detection rule", rsErr.Error())
+			return score, rsErr
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		// Sort the keys to get a stable output
+		keys := make([]string, 0, len(cm.Data))
+		for k := range cm.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if f, ok := rs.scan(key + "=" + cm.Data[key]); ok {
+				score.LowerGrade(gradeFor(f))
+				score.AddComment(key,
+					fmt.Sprintf("The ConfigMap contains a possible secret (%s)", f.rule),
+					fmt.Sprintf("The value %s looks like a secret. ConfigMaps are not intended for confidential data, use a Secret instead", redact(f.match)))
+			}
+		}
+
+		return
+	}
+}
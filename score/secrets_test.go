@@ -0,0 +1,78 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+func TestContainerSecretMaterial(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-secret-material.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-secret-material": {}},
+	}, "Container Secret Material", scorecard.GradeCritical)
+	assert.Equal(t, []scorecard.TestScoreComment{
+		{
+			Path:        "app",
+			Summary:     "Environment variable AWS_ACCESS_KEY_ID contains a possible secret (aws-access-key-id)",
+			Description: "The value <redacted, 20 characters> looks like a secret. Store the value in a Secret and reference it with env.valueFrom.secretKeyRef",
+		},
+		{
+			Path:        "app",
+			Summary:     "command[1] contains a possible secret (password-assignment)",
+			Description: "The value <redacted, 37 characters> looks like a secret. Store the value in a Secret and pass it to the container as an environment variable, or mount it as a file",
+		},
+	}, comments)
+}
+
+func TestContainerSecretMaterialDisabledRules(t *testing.T) {
+	t.Parallel()
+	summaries := getSummaries(t, []ks.NamedReader{testFile("pod-secret-material.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-secret-material": {}},
+		SecretPolicy: config.SecretPolicy{
+			DisabledRules: map[string]struct{}{"aws-access-key-id": {}, "password-assignment": {}},
+		},
+	}, "Container Secret Material")
+	assert.Equal(t, []string{
+		"Environment variable AWS_ACCESS_KEY_ID contains a possible secret (high-entropy-string)",
+		"command[1] contains a possible secret (high-entropy-string)",
+	}, summaries)
+}
+
+func TestSecretMaterialInvalidRule(t *testing.T) {
+	t.Parallel()
+	_, err := testScore([]ks.NamedReader{testFile("configmap-secret-material.yaml")}, nil, &config.RunConfiguration{
+		SecretPolicy: config.SecretPolicy{Rules: []string{"broken=("}},
+	})
+	assert.Error(t, err)
+}
+
+func TestContainerSecretMaterialAllGood(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-security-context-all-good.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-secret-material": {}},
+	}, "Container Secret Material", scorecard.GradeAllOK)
+}
+
+func TestConfigMapSecretMaterial(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("configmap-secret-material.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"configmap-secret-material": {}},
+	}, "ConfigMap Secret Material", scorecard.GradeCritical)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "app.properties", comments[0].Path)
+	assert.Equal(t, "The ConfigMap contains a possible secret (password-assignment)", comments[0].Summary)
+	assert.NotContains(t, comments[0].Description, "hunter22")
+	assert.Equal(t, "tls.key", comments[1].Path)
+	assert.Equal(t, "The ConfigMap contains a possible secret (private-key)", comments[1].Summary)
+}
+
+func TestConfigMapSecretMaterialAllGood(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("configmap-multi-dash.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"configmap-secret-material": {}},
+	}, "ConfigMap Secret Material", scorecard.GradeAllOK)
+}
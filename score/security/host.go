@@ -0,0 +1,216 @@
+package security
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/scorecard"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// sensitiveHostPaths are paths on the host that can be used to take over the node if they are writable from a container
+var sensitiveHostPaths = []string{
+	"/",
+	"/boot",
+	"/dev",
+	"/etc",
+	"/proc",
+	"/root",
+	"/sys",
+	"/var/lib/kubelet",
+}
+
+// runtimeSockets are the sockets of the container runtimes, mounting them gives control over the node even when read-only
+var runtimeSockets = []string{
+	"/run/containerd/containerd.sock",
+	"/run/crio/crio.sock",
+	"/var/run/containerd/containerd.sock",
+	"/var/run/crio/crio.sock",
+	"/var/run/docker.sock",
+}
+
+// hostPathAllowlist is a list of allowed hostPath prefixes, optionally limited to objects of a specific kind
+type hostPathAllowlist []hostPathAllowlistEntry
+
+type hostPathAllowlistEntry struct {
+	kind   string
+	prefix string
+}
+
+// newHostPathAllowlist parses entries on the format "/var/log" or "DaemonSet:/var/log"
+func newHostPathAllowlist(entries []string) hostPathAllowlist {
+	var res hostPathAllowlist
+	for _, e := range entries {
+		var kind string
+		if !strings.HasPrefix(e, "/") {
+			if k, p, ok := strings.Cut(e, ":"); ok {
+				kind, e = k, p
+			}
+		}
+		res = append(res, hostPathAllowlistEntry{kind: kind, prefix: path.Clean(e)})
+	}
+	return res
+}
+
+func (l hostPathAllowlist) allows(kind, hostPath string) bool {
+	for _, e := range l {
+		if e.kind != "" && !strings.EqualFold(e.kind, kind) {
+			continue
+		}
+		if pathHasPrefix(hostPath, e.prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathHasPrefix returns true if p is prefix, or is a file or directory inside of prefix
+func pathHasPrefix(p, prefix string) bool {
+	p = path.Clean(p)
+	if p == prefix || prefix == "/" {
+		return true
+	}
+	return strings.HasPrefix(p, prefix+"/")
+}
+
+func isSensitiveHostPath(p string) bool {
+	p = path.Clean(p)
+	for _, s := range sensitiveHostPaths {
+		// Only the root itself is sensitive, not every path on the host
+		if s == "/" {
+			if p == "/" {
+				return true
+			}
+			continue
+		}
+		if pathHasPrefix(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRuntimeSocket(p string) bool {
+	p = path.Clean(p)
+	for _, s := range runtimeSockets {
+		if pathHasPrefix(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// podHostNamespaces checks that the pod does not use the hosts network, PID, or IPC namespaces, and that no container uses a hostPort
+// Objects of a kind in allowedKinds are not checked
+func podHostNamespaces(allowedKinds map[string]struct{}) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		if _, ok := allowedKinds[ps.GetTypeMeta().Kind]; ok {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", fmt.Sprintf("Skipped because host namespaces are allowed for %s", ps.GetTypeMeta().Kind), "")
+			return
+		}
+
+		spec := ps.GetPodTemplateSpec().Spec
+		score.Grade = scorecard.GradeAllOK
+
+		namespaces := []struct {
+			enabled bool
+			field   string
+			name    string
+		}{
+			{spec.HostNetwork, "hostNetwork", "network"},
+			{spec.HostPID, "hostPID", "PID"},
+			{spec.HostIPC, "hostIPC", "IPC"},
+		}
+		for _, ns := range namespaces {
+			if !ns.enabled {
+				continue
+			}
+			score.Grade = scorecard.GradeCritical
+			score.AddComment("", fmt.Sprintf("The pod uses the host %s namespace", ns.name),
+				fmt.Sprintf("Set %s to false. Sharing the hosts %s namespace removes the isolation between the pod and the node.", ns.field, ns.name))
+		}
+
+		for _, container := range internal.AllContainers(spec) {
+			for _, port := range container.Ports {
+				if port.HostPort == 0 {
+					continue
+				}
+				score.LowerGrade(scorecard.GradeWarning)
+				score.AddComment(container.Name, fmt.Sprintf("The container uses hostPort %d", port.HostPort),
+					"Remove ports.hostPort. Host ports limit where the pod can be scheduled, and expose the container directly on the nodes network. Use a Service to expose the container instead.")
+			}
+		}
+
+		return
+	}
+}
+
+// podHostPathVolumes checks for pods that mount paths from the host
+// Paths matching the allowlist are not reported, runtime sockets and writable mounts of sensitive paths are critical
+func podHostPathVolumes(allowedHostPaths []string) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	allowlist := newHostPathAllowlist(allowedHostPaths)
+
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		spec := ps.GetPodTemplateSpec().Spec
+		kind := ps.GetTypeMeta().Kind
+		allContainers := internal.AllContainers(spec)
+
+		score.Grade = scorecard.GradeAllOK
+
+		for _, volume := range spec.Volumes {
+			if volume.HostPath == nil {
+				continue
+			}
+			hostPath := volume.HostPath.Path
+
+			if allowlist.allows(kind, hostPath) {
+				continue
+			}
+
+			if isRuntimeSocket(hostPath) {
+				score.Grade = scorecard.GradeCritical
+				for _, containerName := range volumeMounts(allContainers, volume.Name, false) {
+					score.AddComment(containerName, fmt.Sprintf("The container has access to the container runtime socket %s", hostPath),
+						fmt.Sprintf("The volume %s mounts %s from the host, which can be used to take over the node, also when mounted as readOnly. Remove the hostPath volume.", volume.Name, hostPath))
+				}
+				continue
+			}
+
+			writableBy := volumeMounts(allContainers, volume.Name, true)
+
+			if isSensitiveHostPath(hostPath) && len(writableBy) > 0 {
+				score.Grade = scorecard.GradeCritical
+				for _, containerName := range writableBy {
+					score.AddComment(containerName, fmt.Sprintf("The container has write access to the sensitive host path %s", hostPath),
+						fmt.Sprintf("The volume %s mounts %s from the host, which can be used to take over the node. Remove the hostPath volume, or set readOnly to true on the volumeMount.", volume.Name, hostPath))
+				}
+				continue
+			}
+
+			score.LowerGrade(scorecard.GradeWarning)
+			score.AddComment(volume.Name, fmt.Sprintf("The pod mounts the host path %s", hostPath),
+				"hostPath volumes give the pod access to the nodes filesystem. Use a different volume type, or allow the path with --allow-host-path if the access is required.")
+		}
+
+		return
+	}
+}
+
+// volumeMounts returns the names of the containers that mount volumeName, if writableOnly is set mounts with readOnly set are ignored
+func volumeMounts(containers []corev1.Container, volumeName string, writableOnly bool) []string {
+	var res []string
+	for _, container := range containers {
+		for _, mount := range container.VolumeMounts {
+			if mount.Name == volumeName && !(writableOnly && mount.ReadOnly) {
+				res = append(res, container.Name)
+				break
+			}
+		}
+	}
+	return res
+}
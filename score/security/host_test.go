@@ -0,0 +1,36 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSensitiveHostPath(t *testing.T) {
+	t.Parallel()
+	assert.True(t, isSensitiveHostPath("/"))
+	assert.True(t, isSensitiveHostPath("/etc"))
+	assert.True(t, isSensitiveHostPath("/etc/kubernetes/"))
+	assert.True(t, isSensitiveHostPath("/proc/1/root"))
+	assert.False(t, isSensitiveHostPath("/etcd"))
+	assert.False(t, isSensitiveHostPath("/var/log"))
+	assert.False(t, isSensitiveHostPath("/var/run"))
+}
+
+func TestIsRuntimeSocket(t *testing.T) {
+	t.Parallel()
+	assert.True(t, isRuntimeSocket("/var/run/docker.sock"))
+	assert.True(t, isRuntimeSocket("/run/containerd//containerd.sock"))
+	assert.False(t, isRuntimeSocket("/var/run"))
+	assert.False(t, isRuntimeSocket("/var/run/docker.socket"))
+}
+
+func TestHostPathAllowlist(t *testing.T) {
+	t.Parallel()
+	l := newHostPathAllowlist([]string{"/var/log/", "DaemonSet:/var/lib/docker/containers"})
+	assert.True(t, l.allows("Deployment", "/var/log"))
+	assert.True(t, l.allows("Deployment", "/var/log/pods"))
+	assert.False(t, l.allows("Deployment", "/var/logs"))
+	assert.True(t, l.allows("DaemonSet", "/var/lib/docker/containers"))
+	assert.False(t, l.allows("Deployment", "/var/lib/docker/containers"))
+}
@@ -1,18 +1,43 @@
 package security
 
 import (
+	"fmt"
+	"strings"
+
 	ks "github.com/zegl/kube-score/domain"
 	"github.com/zegl/kube-score/score/checks"
+	"github.com/zegl/kube-score/score/internal"
 	"github.com/zegl/kube-score/scorecard"
 	corev1 "k8s.io/api/core/v1"
 )
 
-func Register(allChecks *checks.Checks) {
+// dangerousCapabilities are capabilities that grant a container (close to) host level access
+var dangerousCapabilities = []string{
+	"ALL",
+	"BPF",
+	"DAC_READ_SEARCH",
+	"NET_ADMIN",
+	"NET_RAW",
+	"SYS_ADMIN",
+	"SYS_BOOT",
+	"SYS_MODULE",
+	"SYS_PTRACE",
+	"SYS_RAWIO",
+	"SYS_TIME",
+}
+
+func Register(allChecks *checks.Checks, allowedCapabilities map[string]struct{}, allowedHostPaths []string, allowedHostNamespaceKinds map[string]struct{}) {
 	allChecks.RegisterPodCheck("Container Security Context User Group ID", `Makes sure that all pods have a security context with valid UID and GID set `, containerSecurityContextUserGroupID)
 	allChecks.RegisterPodCheck("Container Security Context Privileged", "Makes sure that all pods have a unprivileged security context set", containerSecurityContextPrivileged)
 	allChecks.RegisterPodCheck("Container Security Context ReadOnlyRootFilesystem", "Makes sure that all pods have a security context with read only filesystem set", containerSecurityContextReadOnlyRootFilesystem)
 
+	allChecks.RegisterOptionalPodCheck("Pod Host Namespaces", "Makes sure that pods do not use the host network, PID, or IPC namespaces, and that no container uses a hostPort. The --allow-host-namespace-kind flag can be used to allow this for a kind", podHostNamespaces(allowedHostNamespaceKinds))
+	allChecks.RegisterOptionalPodCheck("Pod HostPath Volumes", "Makes sure that pods do not mount paths from the host. The --allow-host-path flag can be used to allow a path prefix", podHostPathVolumes(allowedHostPaths))
+	allChecks.RegisterOptionalPodCheck("Container Security Context Dangerous Capabilities", "Makes sure that no container adds dangerous capabilities, such as SYS_ADMIN or NET_RAW. The --allow-capability flag can be used to allow a capability", containerSecurityContextDangerousCapabilities(allowedCapabilities))
+
 	allChecks.RegisterOptionalPodCheck("Container Seccomp Profile", `Makes sure that all pods have at a seccomp policy configured.`, podSeccompProfile)
+	allChecks.RegisterOptionalPodCheck("Container Security Context Capabilities Drop All", `Makes sure that all containers drop all capabilities`, containerSecurityContextCapabilitiesDropAll)
+	allChecks.RegisterOptionalPodCheck("Container Security Context Privilege Escalation", `Makes sure that all containers have allowPrivilegeEscalation set to false`, containerSecurityContextPrivilegeEscalation)
 }
 
 // containerSecurityContextReadOnlyRootFilesystem checks for pods using writeable root filesystems
@@ -129,3 +154,86 @@ func podSeccompProfile(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
 
 	return
 }
+
+// normalizeCapability converts a capability to the format used by Kubernetes, "CAP_NET_RAW" and "net_raw" both become "NET_RAW"
+func normalizeCapability(c corev1.Capability) string {
+	return strings.TrimPrefix(strings.ToUpper(string(c)), "CAP_")
+}
+
+// containerSecurityContextCapabilitiesDropAll checks that all containers (including init and ephemeral containers) drop ALL capabilities
+func containerSecurityContextCapabilitiesDropAll(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range internal.AllContainers(ps.GetPodTemplateSpec().Spec) {
+		dropsAll := false
+		if container.SecurityContext != nil && container.SecurityContext.Capabilities != nil {
+			for _, c := range container.SecurityContext.Capabilities.Drop {
+				if normalizeCapability(c) == "ALL" {
+					dropsAll = true
+					break
+				}
+			}
+		}
+
+		if !dropsAll {
+			score.Grade = scorecard.GradeWarning
+			score.AddComment(container.Name, "The container does not drop all capabilities", "Set securityContext.capabilities.drop to [\"ALL\"], and only add back the capabilities that the container needs in securityContext.capabilities.add")
+		}
+	}
+
+	return
+}
+
+// containerSecurityContextDangerousCapabilities checks that no container adds a capability that is in the list of dangerous capabilities
+// Capabilities in allowedCapabilities are never reported
+func containerSecurityContextDangerousCapabilities(allowedCapabilities map[string]struct{}) func(ks.PodSpecer) (scorecard.TestScore, error) {
+	allowed := make(map[string]struct{})
+	for c := range allowedCapabilities {
+		allowed[normalizeCapability(corev1.Capability(c))] = struct{}{}
+	}
+
+	dangerous := make(map[string]struct{})
+	for _, c := range dangerousCapabilities {
+		if _, ok := allowed[c]; !ok {
+			dangerous[c] = struct{}{}
+		}
+	}
+
+	return func(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+		score.Grade = scorecard.GradeAllOK
+
+		for _, container := range internal.AllContainers(ps.GetPodTemplateSpec().Spec) {
+			if container.SecurityContext == nil || container.SecurityContext.Capabilities == nil {
+				continue
+			}
+			for _, c := range container.SecurityContext.Capabilities.Add {
+				name := normalizeCapability(c)
+				if _, ok := dangerous[name]; !ok {
+					continue
+				}
+				score.Grade = scorecard.GradeCritical
+				score.AddComment(container.Name,
+					fmt.Sprintf("The container adds the dangerous capability %s", name),
+					fmt.Sprintf("Remove %s from securityContext.capabilities.add. Dangerous capabilities can be used to escape the container, or to interfere with the host and other workloads. If the capability is required, it can be allowed with --allow-capability=%s", name, name),
+				)
+			}
+		}
+
+		return
+	}
+}
+
+// containerSecurityContextPrivilegeEscalation checks that all containers (including init and ephemeral containers) have allowPrivilegeEscalation set to false
+func containerSecurityContextPrivilegeEscalation(ps ks.PodSpecer) (score scorecard.TestScore, err error) {
+	score.Grade = scorecard.GradeAllOK
+
+	for _, container := range internal.AllContainers(ps.GetPodTemplateSpec().Spec) {
+		sec := container.SecurityContext
+		if sec == nil || sec.AllowPrivilegeEscalation == nil || *sec.AllowPrivilegeEscalation {
+			score.Grade = scorecard.GradeCritical
+			score.AddComment(container.Name, "The container allows privilege escalation", "Set securityContext.allowPrivilegeEscalation to false. Privilege escalation is allowed by default, and lets a process gain more privileges than its parent process, for example via setuid binaries.")
+		}
+	}
+
+	return
+}
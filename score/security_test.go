@@ -162,3 +162,146 @@ func TestContainerSecurityContextReadOnlyRootFilesystemNoSecurityContext(t *test
 		Description: "Set securityContext to run the container in a more secure context.",
 	})
 }
+
+func TestContainerSecurityContextDangerousCapabilities(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-security-context-capabilities-dangerous.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-security-context-dangerous-capabilities": {}},
+	}, "Container Security Context Dangerous Capabilities", scorecard.GradeCritical)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "init", comments[0].Path)
+	assert.Equal(t, "The container adds the dangerous capability NET_RAW", comments[0].Summary)
+	assert.Equal(t, "foobar", comments[1].Path)
+	assert.Equal(t, "The container adds the dangerous capability SYS_ADMIN", comments[1].Summary)
+	assert.Equal(t, "debugger", comments[2].Path)
+	assert.Equal(t, "The container adds the dangerous capability SYS_PTRACE", comments[2].Summary)
+}
+
+func TestContainerSecurityContextDangerousCapabilitiesAllowed(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-security-context-capabilities-dangerous.yaml")}, nil, &config.RunConfiguration{
+		AllowedCapabilities:  map[string]struct{}{"net_raw": {}, "CAP_SYS_PTRACE": {}},
+		EnabledOptionalTests: map[string]struct{}{"container-security-context-dangerous-capabilities": {}},
+	}, "Container Security Context Dangerous Capabilities", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "foobar", comments[0].Path)
+}
+
+func TestContainerSecurityContextDangerousCapabilitiesAllGood(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-security-context-capabilities-drop-all.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-security-context-dangerous-capabilities": {}},
+	}, "Container Security Context Dangerous Capabilities", scorecard.GradeAllOK)
+}
+
+func TestContainerSecurityContextCapabilitiesDropAll(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-security-context-capabilities-dangerous.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-security-context-capabilities-drop-all": {}},
+	}, "Container Security Context Capabilities Drop All", scorecard.GradeWarning)
+	assert.Len(t, comments, 3)
+	for _, c := range comments {
+		assert.Equal(t, "The container does not drop all capabilities", c.Summary)
+	}
+}
+
+func TestContainerSecurityContextCapabilitiesDropAllAllGood(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-security-context-capabilities-drop-all.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-security-context-capabilities-drop-all": {}},
+	}, "Container Security Context Capabilities Drop All", scorecard.GradeAllOK)
+}
+
+func TestContainerSecurityContextPrivilegeEscalation(t *testing.T) {
+	t.Parallel()
+	summaries := getSummaries(t, []ks.NamedReader{testFile("pod-security-context-capabilities-dangerous.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-security-context-privilege-escalation": {}},
+	}, "Container Security Context Privilege Escalation")
+	assert.Len(t, summaries, 3)
+	assert.Contains(t, summaries, "The container allows privilege escalation")
+}
+
+func TestContainerSecurityContextPrivilegeEscalationAllGood(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-security-context-capabilities-drop-all.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"container-security-context-privilege-escalation": {}},
+	}, "Container Security Context Privilege Escalation", scorecard.GradeAllOK)
+}
+
+func TestPodHostNamespaces(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("daemonset-host-access.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-host-namespaces": {}},
+	}, "Pod Host Namespaces", scorecard.GradeCritical)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "The pod uses the host network namespace", comments[0].Summary)
+	assert.Equal(t, "The pod uses the host PID namespace", comments[1].Summary)
+	assert.Equal(t, "shipper", comments[2].Path)
+	assert.Equal(t, "The container uses hostPort 8080", comments[2].Summary)
+}
+
+func TestPodHostNamespacesAllowedKind(t *testing.T) {
+	t.Parallel()
+	skipped := wasSkipped(t, []ks.NamedReader{testFile("daemonset-host-access.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests:      map[string]struct{}{"pod-host-namespaces": {}},
+		AllowedHostNamespaceKinds: map[string]struct{}{"DaemonSet": {}},
+	}, "Pod Host Namespaces")
+	assert.True(t, skipped)
+}
+
+func TestPodHostNamespacesAllGood(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-host-access-none.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-host-namespaces": {}},
+	}, "Pod Host Namespaces", scorecard.GradeAllOK)
+}
+
+func TestPodHostPathVolumes(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("daemonset-host-access.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-hostpath-volumes": {}},
+	}, "Pod HostPath Volumes", scorecard.GradeCritical)
+	assert.Equal(t, []scorecard.TestScoreComment{
+		{
+			Path:        "logs",
+			Summary:     "The pod mounts the host path /var/log",
+			Description: "hostPath volumes give the pod access to the nodes filesystem. Use a different volume type, or allow the path with --allow-host-path if the access is required.",
+		},
+		{
+			Path:        "shipper",
+			Summary:     "The container has access to the container runtime socket /var/run/docker.sock",
+			Description: "The volume docker mounts /var/run/docker.sock from the host, which can be used to take over the node, also when mounted as readOnly. Remove the hostPath volume.",
+		},
+		{
+			Path:        "etc",
+			Summary:     "The pod mounts the host path /etc",
+			Description: "hostPath volumes give the pod access to the nodes filesystem. Use a different volume type, or allow the path with --allow-host-path if the access is required.",
+		},
+	}, comments)
+}
+
+func TestPodHostPathVolumesAllowlist(t *testing.T) {
+	t.Parallel()
+	summaries := getSummaries(t, []ks.NamedReader{testFile("daemonset-host-access.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-hostpath-volumes": {}},
+		AllowedHostPaths:     []string{"DaemonSet:/var/log", "Deployment:/etc", "/var/run/docker.sock"},
+	}, "Pod HostPath Volumes")
+	assert.Equal(t, []string{"The pod mounts the host path /etc"}, summaries)
+}
+
+func TestPodHostPathVolumesRuntimeSocketReadOnly(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-hostpath-runtime-socket-readonly.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-hostpath-volumes": {}},
+	}, "Pod HostPath Volumes", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "foobar", comments[0].Path)
+	assert.Equal(t, "The container has access to the container runtime socket /run/containerd/containerd.sock", comments[0].Summary)
+}
+
+func TestPodHostPathVolumesAllGood(t *testing.T) {
+	t.Parallel()
+	testExpectedScoreWithConfig(t, []ks.NamedReader{testFile("pod-host-access-none.yaml")}, nil, &config.RunConfiguration{
+		EnabledOptionalTests: map[string]struct{}{"pod-hostpath-volumes": {}},
+	}, "Pod HostPath Volumes", scorecard.GradeAllOK)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ks "github.com/zegl/kube-score/domain"
+	"github.com/zegl/kube-score/score/internal"
+	"github.com/zegl/kube-score/scorecard"
+)
+
+type targetWorkload struct {
+	name       string
+	containers []corev1.Container
+}
+
+// targetedWorkloads returns the pods and pod templates that are targeted by the service
+func targetedWorkloads(service corev1.Service, pods []ks.Pod, podspecers []ks.PodSpecer) []targetWorkload {
+	var res []targetWorkload
+	add := func(kind, name string, template corev1.PodTemplateSpec) {
+		if !internal.PodIsTargetedByService(template, service) {
+			return
+		}
+		// Sidecars can serve traffic, regular init containers can not
+		res = append(res, targetWorkload{name: kind + " " + name, containers: internal.RunningContainers(template.Spec)})
+	}
+
+	for _, p := range pods {
+		pod := p.Pod()
+		add(pod.Kind, pod.Name, corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec})
+	}
+	for _, ps := range podspecers {
+		add(ps.GetTypeMeta().Kind, ps.GetObjectMeta().Name, ps.GetPodTemplateSpec())
+	}
+	return res
+}
+
+func protocolOrDefault(protocol corev1.Protocol) corev1.Protocol {
+	if protocol == "" {
+		return corev1.ProtocolTCP
+	}
+	return protocol
+}
+
+// serviceTargetPort checks that the targetPort of all ports of the service resolves to a containerPort on the
+// targeted pods, with the same protocol
+//
+// A named targetPort that does not exist in a pod makes the pod be left out of the endpoints for that port. A numeric
+// targetPort does not need to be declared as a containerPort, so a missing declaration is only pointed out.
+func serviceTargetPort(pods []ks.Pod, podspecers []ks.PodSpecer) func(corev1.Service) (scorecard.TestScore, error) {
+	return func(service corev1.Service) (score scorecard.TestScore, err error) {
+		if service.Spec.Type == corev1.ServiceTypeExternalName || len(service.Spec.Selector) == 0 {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because the service does not have a selector", "")
+			return
+		}
+
+		workloads := targetedWorkloads(service, pods, podspecers)
+		if len(workloads) == 0 {
+			score.Grade = scorecard.GradeAllOK
+			score.Skipped = true
+			score.AddComment("", "Skipped because the service does not target any pods", "")
+			return
+		}
+
+		score.Grade = scorecard.GradeAllOK
+
+		for _, servicePort := range service.Spec.Ports {
+			targetPort := servicePort.TargetPort
+			if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+				// The targetPort defaults to the port
+				targetPort = intstr.FromInt32(servicePort.Port)
+			}
+			protocol := protocolOrDefault(servicePort.Protocol)
+
+			path := servicePort.Name
+			if path == "" {
+				path = fmt.Sprintf("%d", servicePort.Port)
+			}
+
+			// The container port that a named targetPort resolves to in each workload
+			resolved := make(map[int32][]string)
+
+			for _, w := range workloads {
+				var match *corev1.ContainerPort
+				for _, c := range w.containers {
+					for i, cp := range c.Ports {
+						if (targetPort.Type == intstr.String && cp.Name == targetPort.StrVal) ||
+							(targetPort.Type == intstr.Int && cp.ContainerPort == targetPort.IntVal) {
+							match = &c.Ports[i]
+							break
+						}
+					}
+					if match != nil {
+						break
+					}
+				}
+
+				if match == nil {
+					if targetPort.Type == intstr.String {
+						score.LowerGrade(scorecard.GradeCritical)
+						score.AddComment(path, fmt.Sprintf("The targetPort %s does not exist in %s", targetPort.String(), w.name),
+							"The named targetPort is not the name of any containerPort in the pod. The pod will not receive any traffic on this port.")
+					} else {
+						score.LowerGrade(scorecard.GradeAlmostOK)
+						score.AddComment(path, fmt.Sprintf("The targetPort %s is not a containerPort in %s", targetPort.String(), w.name),
+							"No container declares the targetPort as a containerPort. Traffic is still sent to the port, but make sure that the targetPort is correct, and consider declaring the port in the container.")
+					}
+					continue
+				}
+
+				if protocolOrDefault(match.Protocol) != protocol {
+					score.LowerGrade(scorecard.GradeCritical)
+					score.AddComment(path, fmt.Sprintf("The protocol of the targetPort %s does not match in %s", targetPort.String(), w.name),
+						fmt.Sprintf("The service port uses %s, but the containerPort uses %s", protocol, protocolOrDefault(match.Protocol)))
+				}
+
+				if targetPort.Type == intstr.String {
+					resolved[match.ContainerPort] = append(resolved[match.ContainerPort], w.name)
+				}
+			}
+
+			if len(resolved) > 1 {
+				var resolutions []string
+				for port, names := range resolved {
+					resolutions = append(resolutions, fmt.Sprintf("%d in %s", port, strings.Join(names, ", ")))
+				}
+				sort.Strings(resolutions)
+				score.LowerGrade(scorecard.GradeWarning)
+				score.AddComment(path, fmt.Sprintf("The targetPort %s resolves to different ports", targetPort.String()),
+					fmt.Sprintf("The named targetPort resolves to %s. Make sure that all targeted pods serve the same traffic on the port.", strings.Join(resolutions, "; ")))
+			}
+		}
+
+		return
+	}
+}
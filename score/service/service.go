@@ -11,6 +11,7 @@ import (
 
 func Register(allChecks *checks.Checks, pods ks.Pods, podspeccers ks.PodSpeccers) {
 	allChecks.RegisterServiceCheck("Service Targets Pod", `Makes sure that all Services targets a Pod`, serviceTargetsPod(pods.Pods(), podspeccers.PodSpeccers()))
+	allChecks.RegisterServiceCheck("Service Target Port", `Makes sure that the targetPort of all Service ports resolves to a containerPort with the same protocol in the targeted Pods`, serviceTargetPort(pods.Pods(), podspeccers.PodSpeccers()))
 	allChecks.RegisterServiceCheck("Service Type", `Makes sure that the Service type is not NodePort`, serviceType)
 }
 
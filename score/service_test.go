@@ -3,6 +3,8 @@ package score
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/zegl/kube-score/scorecard"
 )
 
@@ -75,3 +77,37 @@ func TestServiceTypeDefault(t *testing.T) {
 	t.Parallel()
 	testExpectedScore(t, "service-type-default.yaml", "Service Type", scorecard.GradeAllOK)
 }
+
+func TestServiceTargetPortOk(t *testing.T) {
+	t.Parallel()
+	testExpectedScore(t, "service-target-port-ok.yaml", "Service Target Port", scorecard.GradeAllOK)
+}
+
+func TestServiceTargetPortNamedMissing(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "service-target-port-named-missing.yaml", "Service Target Port", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "http", comments[0].Path)
+	assert.Equal(t, "The targetPort web does not exist in Deployment app", comments[0].Summary)
+}
+
+func TestServiceTargetPortNumericUndeclared(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "service-target-port-numeric-undeclared.yaml", "Service Target Port", scorecard.GradeAlmostOK)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The targetPort 80 is not a containerPort in Deployment app", comments[0].Summary)
+}
+
+func TestServiceTargetPortProtocolMismatch(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "service-target-port-protocol-mismatch.yaml", "Service Target Port", scorecard.GradeCritical)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The service port uses UDP, but the containerPort uses TCP", comments[0].Description)
+}
+
+func TestServiceTargetPortInconsistent(t *testing.T) {
+	t.Parallel()
+	comments := testExpectedScore(t, "service-target-port-inconsistent.yaml", "Service Target Port", scorecard.GradeWarning)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "The named targetPort resolves to 8080 in Deployment app-a; 9090 in Deployment app-b. Make sure that all targeted pods serve the same traffic on the port.", comments[0].Description)
+}
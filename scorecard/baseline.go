@@ -0,0 +1,176 @@
+package scorecard
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// BaselineVersion is the version of the baseline file format
+const BaselineVersion = 1
+
+// Baseline is a set of known findings. Findings that are in the baseline are hidden, so that only new findings are
+// reported.
+type Baseline struct {
+	Version  int               `json:"version"`
+	Findings []BaselineFinding `json:"findings"`
+}
+
+// BaselineFinding is a failed check of an object, or one of the comments of the failed check. The fingerprint and
+// the summary are used to match the finding, the other fields are for the readers of the baseline.
+// Grade is the grade of the check when the baseline was written.
+type BaselineFinding struct {
+	Fingerprint string `json:"fingerprint"`
+	CheckID     string `json:"check_id"`
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	Path        string `json:"path,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Grade       int    `json:"grade,omitempty"`
+}
+
+func (f BaselineFinding) String() string {
+	s := f.CheckID + " " + f.Kind + "/"
+	if f.Namespace != "" {
+		s += f.Namespace + "/"
+	}
+	s += f.Name
+	if f.Path != "" {
+		s += " (" + f.Path + ")"
+	}
+	return s
+}
+
+// ReadBaseline reads a baseline that has been written with Baseline.Write
+func ReadBaseline(r io.Reader) (Baseline, error) {
+	var b Baseline
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return b, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	if b.Version != BaselineVersion {
+		return b, fmt.Errorf("unsupported baseline version %d, expected %d", b.Version, BaselineVersion)
+	}
+	return b, nil
+}
+
+func (b Baseline) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(b)
+}
+
+// Baseline returns the findings of all failed checks
+func (s Scorecard) Baseline() Baseline {
+	b := Baseline{Version: BaselineVersion, Findings: []BaselineFinding{}}
+	s.eachFinding(func(key string, so *ScoredObject, ts *TestScore, comment TestScoreComment, fingerprint string) bool {
+		b.Findings = append(b.Findings, BaselineFinding{
+			Fingerprint: fingerprint,
+			CheckID:     ts.Check.ID,
+			Kind:        so.TypeMeta.Kind,
+			Namespace:   so.ObjectMeta.Namespace,
+			Name:        so.ObjectMeta.Name,
+			Path:        comment.Path,
+			Summary:     comment.Summary,
+			Grade:       int(ts.Grade),
+		})
+		return false
+	})
+	return b
+}
+
+// ApplyBaseline hides the findings that are in the baseline. The comments of failed checks that are in the baseline
+// are removed, and checks where all comments are in the baseline are skipped. A finding is only hidden if it has the
+// same summary as in the baseline, so that a different problem with the same path is reported.
+//
+// The grade of a check where only some of the comments are in the baseline is kept if it's worse than in the baseline,
+// as it's then caused by the new findings. Otherwise the grade can be caused by the hidden findings, and it's raised to
+// a warning.
+//
+// The findings of the baseline that were not found are returned, they have been fixed and can be removed from the
+// baseline.
+func (s Scorecard) ApplyBaseline(b Baseline) []BaselineFinding {
+	known := make(map[string]BaselineFinding, len(b.Findings))
+	for _, f := range b.Findings {
+		known[baselineKey(f.Fingerprint, f.Summary)] = f
+	}
+
+	found := make(map[string]struct{})
+	baselineGrades := make(map[*TestScore]Grade)
+	s.eachFinding(func(_ string, so *ScoredObject, ts *TestScore, comment TestScoreComment, fingerprint string) bool {
+		key := baselineKey(fingerprint, comment.Summary)
+		f, ok := known[key]
+		if !ok {
+			return false
+		}
+		found[key] = struct{}{}
+		baselineGrades[ts] = Grade(f.Grade)
+		return true
+	})
+
+	for ts, grade := range baselineGrades {
+		if ts.Skipped || (grade > 0 && ts.Grade < grade) {
+			continue
+		}
+		if ts.Grade < GradeWarning {
+			ts.Grade = GradeWarning
+		}
+	}
+
+	var fixed []BaselineFinding
+	for _, f := range b.Findings {
+		if _, ok := found[baselineKey(f.Fingerprint, f.Summary)]; !ok {
+			fixed = append(fixed, f)
+		}
+	}
+	return fixed
+}
+
+func baselineKey(fingerprint, summary string) string {
+	return fingerprint + "\x00" + summary
+}
+
+// eachFinding calls fn for each comment of all failed checks in a stable order, with the key of the object. Checks
+// without comments are passed with an empty comment. If fn returns true the finding is removed from the check, and the
+// check is skipped when all of its findings have been removed.
+func (s Scorecard) eachFinding(fn func(key string, so *ScoredObject, ts *TestScore, comment TestScoreComment, fingerprint string) bool) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		so := s[key]
+		for i := range so.Checks {
+			ts := &so.Checks[i]
+			if ts.Skipped || ts.Grade > GradeWarning {
+				continue
+			}
+
+			comments := ts.Comments
+			if len(comments) == 0 {
+				comments = []TestScoreComment{{}}
+			}
+
+			fingerprints := so.CommentFingerprints(ts.Check.ID, comments)
+			var kept []TestScoreComment
+			for j, comment := range comments {
+				if !fn(key, so, ts, comment, fingerprints[j]) {
+					kept = append(kept, comment)
+				}
+			}
+
+			if len(kept) == len(comments) {
+				continue
+			}
+			if len(kept) == 0 {
+				ts.Skipped = true
+				ts.Comments = []TestScoreComment{{Summary: fmt.Sprintf("Skipped because %s is in the baseline", ts.Check.ID)}}
+				continue
+			}
+			ts.Comments = kept
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package scorecard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	ks "github.com/zegl/kube-score/domain"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func baselineTestCard(paths ...string) Scorecard {
+	s := New()
+	o := s.NewObject(metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}, metav1.ObjectMeta{Namespace: "foo", Name: "a"}, nil)
+
+	resources := TestScore{Check: ks.Check{ID: "container-resources"}, Grade: GradeCritical}
+	for _, path := range paths {
+		resources.AddComment(path, "CPU limit is not set", "")
+	}
+	o.Checks = append(o.Checks,
+		resources,
+		TestScore{Check: ks.Check{ID: "deployment-replicas"}, Grade: GradeWarning},
+		TestScore{Check: ks.Check{ID: "pod-probes"}, Grade: GradeAllOK},
+		TestScore{Check: ks.Check{ID: "service-type"}, Grade: GradeCritical, Skipped: true},
+	)
+	return s
+}
+
+func TestBaseline(t *testing.T) {
+	s := baselineTestCard("foo", "bar", "bar")
+	b := s.Baseline()
+
+	assert.Equal(t, BaselineVersion, b.Version)
+	assert.Len(t, b.Findings, 4)
+	assert.Equal(t, "container-resources Deployment/foo/a (foo)", b.Findings[0].String())
+	assert.Equal(t, "CPU limit is not set", b.Findings[0].Summary)
+	assert.Equal(t, "deployment-replicas Deployment/foo/a", b.Findings[3].String())
+
+	// Findings with the same path have different fingerprints
+	assert.NotEqual(t, b.Findings[1].Fingerprint, b.Findings[2].Fingerprint)
+
+	var buf bytes.Buffer
+	assert.NoError(t, b.Write(&buf))
+	read, err := ReadBaseline(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, b, read)
+}
+
+func TestReadBaselineInvalid(t *testing.T) {
+	_, err := ReadBaseline(strings.NewReader(`{"version": 2, "findings": []}`))
+	assert.EqualError(t, err, "unsupported baseline version 2, expected 1")
+
+	_, err = ReadBaseline(strings.NewReader(`[]`))
+	assert.Error(t, err)
+}
+
+func TestApplyBaseline(t *testing.T) {
+	b := baselineTestCard("foo", "bar").Baseline()
+
+	// All findings are in the baseline
+	s := baselineTestCard("foo", "bar")
+	fixed := s.ApplyBaseline(b)
+	assert.Empty(t, fixed)
+	assert.False(t, s.AnyBelowOrEqualToGrade(GradeWarning))
+	o := s["Deployment/apps/v1/foo/a"]
+	assert.True(t, o.Checks[0].Skipped)
+	assert.Equal(t, "Skipped because container-resources is in the baseline", o.Checks[0].Comments[0].Summary)
+	assert.True(t, o.Checks[1].Skipped)
+	assert.False(t, o.Checks[2].Skipped)
+
+	// A new finding is not hidden, and the fixed findings are returned
+	s = baselineTestCard("bar", "baz")
+	fixed = s.ApplyBaseline(b)
+	assert.Len(t, fixed, 1)
+	assert.Equal(t, "foo", fixed[0].Path)
+	o = s["Deployment/apps/v1/foo/a"]
+	assert.False(t, o.Checks[0].Skipped)
+	// The grade is raised to a warning, as it can be caused by the hidden finding
+	assert.Equal(t, GradeWarning, o.Checks[0].Grade)
+	assert.Len(t, o.Checks[0].Comments, 1)
+	assert.Equal(t, "baz", o.Checks[0].Comments[0].Path)
+	assert.True(t, o.Checks[1].Skipped)
+
+	// A second finding with the same path is new
+	s = baselineTestCard("foo", "bar", "bar")
+	assert.Empty(t, s.ApplyBaseline(b))
+	assert.Len(t, s["Deployment/apps/v1/foo/a"].Checks[0].Comments, 1)
+}
+
+func TestApplyBaselineSummary(t *testing.T) {
+	b := baselineTestCard("foo").Baseline()
+	assert.Equal(t, int(GradeCritical), b.Findings[0].Grade)
+
+	// A finding with the same path but a different summary is new
+	s := baselineTestCard("foo")
+	s["Deployment/apps/v1/foo/a"].Checks[0].Comments[0].Summary = "Memory limit is not set"
+	fixed := s.ApplyBaseline(b)
+	assert.Len(t, fixed, 1)
+	assert.Equal(t, "CPU limit is not set", fixed[0].Summary)
+	assert.False(t, s["Deployment/apps/v1/foo/a"].Checks[0].Skipped)
+}
+
+func TestApplyBaselineWorseGrade(t *testing.T) {
+	b := baselineTestCard("foo").Baseline()
+	for i := range b.Findings {
+		b.Findings[i].Grade = int(GradeWarning)
+	}
+
+	// The check is worse than in the baseline, so the grade is caused by the new finding
+	s := baselineTestCard("foo", "bar")
+	assert.Empty(t, s.ApplyBaseline(b))
+	o := s["Deployment/apps/v1/foo/a"]
+	assert.Equal(t, GradeCritical, o.Checks[0].Grade)
+	assert.Len(t, o.Checks[0].Comments, 1)
+	assert.Equal(t, "bar", o.Checks[0].Comments[0].Path)
+}
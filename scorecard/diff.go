@@ -0,0 +1,125 @@
+package scorecard
+
+import (
+	"sort"
+
+	ks "github.com/zegl/kube-score/domain"
+)
+
+// Finding is a comment of a failed check, or a failed check without comments
+type Finding struct {
+	Fingerprint string
+	Check       ks.Check
+	Grade       Grade
+	Comment     TestScoreComment
+}
+
+// ObjectDiff is the difference between the findings of an object in two scorecards. Objects are identified by their
+// kind, API version, namespace and name, and findings by their fingerprint.
+type ObjectDiff struct {
+	// Key is the key of the object in the scorecards
+	Key string
+
+	// Old is nil if the object was added, and New is nil if the object was removed
+	Old *ScoredObject
+	New *ScoredObject
+
+	Introduced []Finding
+	Resolved   []Finding
+
+	// Unchanged are the findings that are in both scorecards, with the grade of the new scorecard
+	Unchanged []Finding
+}
+
+// Object returns the new version of the object, or the old version if the object was removed
+func (d ObjectDiff) Object() *ScoredObject {
+	if d.New != nil {
+		return d.New
+	}
+	return d.Old
+}
+
+// Changed is true if any findings were introduced or resolved
+func (d ObjectDiff) Changed() bool {
+	return len(d.Introduced) > 0 || len(d.Resolved) > 0
+}
+
+// ScorecardDiff is the difference between the findings of two scorecards
+type ScorecardDiff struct {
+	Old Summary
+	New Summary
+
+	Introduced int
+	Resolved   int
+	Unchanged  int
+
+	// Objects are the objects of both scorecards, sorted by key
+	Objects []ObjectDiff
+}
+
+// Diff compares the findings of the objects in two scorecards, for example before and after a change of the manifests
+func Diff(old, new Scorecard) ScorecardDiff {
+	oldFindings, newFindings := old.findings(), new.findings()
+
+	keys := make([]string, 0, len(old)+len(new))
+	for k := range old {
+		keys = append(keys, k)
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	res := ScorecardDiff{
+		Old:     old.Summary().Overall,
+		New:     new.Summary().Overall,
+		Objects: make([]ObjectDiff, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		d := ObjectDiff{Key: key, Old: old[key], New: new[key]}
+
+		oldByFingerprint := make(map[string]struct{}, len(oldFindings[key]))
+		for _, f := range oldFindings[key] {
+			oldByFingerprint[f.Fingerprint] = struct{}{}
+		}
+		newByFingerprint := make(map[string]struct{}, len(newFindings[key]))
+		for _, f := range newFindings[key] {
+			newByFingerprint[f.Fingerprint] = struct{}{}
+			if _, ok := oldByFingerprint[f.Fingerprint]; ok {
+				d.Unchanged = append(d.Unchanged, f)
+			} else {
+				d.Introduced = append(d.Introduced, f)
+			}
+		}
+		for _, f := range oldFindings[key] {
+			if _, ok := newByFingerprint[f.Fingerprint]; !ok {
+				d.Resolved = append(d.Resolved, f)
+			}
+		}
+
+		res.Introduced += len(d.Introduced)
+		res.Resolved += len(d.Resolved)
+		res.Unchanged += len(d.Unchanged)
+		res.Objects = append(res.Objects, d)
+	}
+
+	return res
+}
+
+// findings returns the findings of each object, by the key of the object
+func (s Scorecard) findings() map[string][]Finding {
+	res := make(map[string][]Finding)
+	s.eachFinding(func(key string, so *ScoredObject, ts *TestScore, comment TestScoreComment, fingerprint string) bool {
+		res[key] = append(res[key], Finding{
+			Fingerprint: fingerprint,
+			Check:       ts.Check,
+			Grade:       ts.Grade,
+			Comment:     comment,
+		})
+		return false
+	})
+	return res
+}
@@ -0,0 +1,60 @@
+package scorecard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	old := New()
+	addObject(old, nil, "Deployment", "foo", "a", GradeCritical, GradeWarning, GradeAllOK)
+	addObject(old, nil, "Service", "foo", "removed", GradeCritical)
+
+	new := New()
+	addObject(new, nil, "Deployment", "foo", "a", GradeWarning, GradeAllOK, GradeWarning)
+	addObject(new, nil, "Service", "foo", "added", GradeAllOK)
+
+	d := Diff(old, new)
+	assert.Equal(t, 1, d.Introduced)
+	assert.Equal(t, 2, d.Resolved)
+	assert.Equal(t, 1, d.Unchanged)
+	// (0 + 4/9 + 1 + 0) / 4
+	assert.Equal(t, 36, d.Old.Score)
+	// (4/9 + 1 + 4/9 + 1) / 4
+	assert.Equal(t, 72, d.New.Score)
+
+	assert.Len(t, d.Objects, 3)
+
+	deployment := d.Objects[0]
+	assert.Equal(t, "a", deployment.Object().ObjectMeta.Name)
+	assert.True(t, deployment.Changed())
+	assert.Len(t, deployment.Introduced, 1)
+	assert.Equal(t, "c", deployment.Introduced[0].Check.ID)
+	assert.Len(t, deployment.Resolved, 1)
+	assert.Equal(t, "b", deployment.Resolved[0].Check.ID)
+	assert.Len(t, deployment.Unchanged, 1)
+	assert.Equal(t, "a", deployment.Unchanged[0].Check.ID)
+	assert.Equal(t, GradeWarning, deployment.Unchanged[0].Grade)
+
+	added := d.Objects[1]
+	assert.Nil(t, added.Old)
+	assert.Equal(t, "added", added.Object().ObjectMeta.Name)
+	assert.False(t, added.Changed())
+
+	removed := d.Objects[2]
+	assert.Nil(t, removed.New)
+	assert.Equal(t, "removed", removed.Object().ObjectMeta.Name)
+	assert.Len(t, removed.Resolved, 1)
+}
+
+func TestDiffEqual(t *testing.T) {
+	s := New()
+	addObject(s, nil, "Deployment", "foo", "a", GradeCritical, GradeWarning)
+
+	d := Diff(s, s)
+	assert.Equal(t, 0, d.Introduced)
+	assert.Equal(t, 0, d.Resolved)
+	assert.Equal(t, 2, d.Unchanged)
+	assert.False(t, d.Objects[0].Changed())
+}
@@ -6,24 +6,32 @@ import (
 	ks "github.com/zegl/kube-score/domain"
 )
 
-func (so *ScoredObject) isEnabled(check ks.Check, annotations, childAnnotations map[string]string) bool {
-	isIn := func(csv string, key string) bool {
-		for _, v := range strings.Split(csv, ",") {
-			v = strings.TrimSpace(v)
-			if v == key {
-				return true
-			}
-			if vals, ok := impliedIgnoreAnnotations[v]; ok {
-				for i := range vals {
-					if vals[i] == key {
-						return true
-					}
+func isIn(csv string, key string) bool {
+	for _, v := range strings.Split(csv, ",") {
+		v = strings.TrimSpace(v)
+		if v == key {
+			return true
+		}
+		if vals, ok := impliedIgnoreAnnotations[v]; ok {
+			for i := range vals {
+				if vals[i] == key {
+					return true
 				}
 			}
 		}
+	}
+	return false
+}
+
+// isIgnored returns true if the check is ignored with the kube-score/ignore annotation
+func (so *ScoredObject) isIgnored(check ks.Check, annotations, childAnnotations map[string]string) bool {
+	if !so.useIgnoreChecksAnnotation {
 		return false
 	}
+	return isIn(annotations[ignoredChecksAnnotation], check.ID) || isIn(childAnnotations[ignoredChecksAnnotation], check.ID)
+}
 
+func (so *ScoredObject) isEnabled(check ks.Check, annotations, childAnnotations map[string]string) bool {
 	if childAnnotations != nil && so.useIgnoreChecksAnnotation && isIn(childAnnotations[ignoredChecksAnnotation], check.ID) {
 		return false
 	}
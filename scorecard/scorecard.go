@@ -1,10 +1,13 @@
 package scorecard
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 
 	"github.com/zegl/kube-score/config"
 	ks "github.com/zegl/kube-score/domain"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -38,6 +41,7 @@ func (s Scorecard) NewObject(typeMeta metav1.TypeMeta, objectMeta metav1.ObjectM
 		useIgnoreChecksAnnotation:   cnf.UseIgnoreChecksAnnotation,
 		useOptionalChecksAnnotation: cnf.UseOptionalChecksAnnotation,
 		enabledOptionalTests:        cnf.EnabledOptionalTests,
+		checkWeights:                cnf.ScorePolicy.CheckWeights,
 	}
 
 	// If this object already exists, return the previous version
@@ -64,9 +68,14 @@ type ScoredObject struct {
 	FileLocation ks.FileLocation
 	Checks       []TestScore
 
+	// QOSClass is the QoS class of objects with a pod spec, and empty for all other objects
+	// It is not a part of the v1 JSON output.
+	QOSClass corev1.PodQOSClass `json:"-"`
+
 	useIgnoreChecksAnnotation   bool
 	useOptionalChecksAnnotation bool
 	enabledOptionalTests        map[string]struct{}
+	checkWeights                map[string]float64
 }
 
 func (so *ScoredObject) AnyBelowOrEqualToGrade(threshold Grade) bool {
@@ -82,6 +91,49 @@ func (so *ScoredObject) resourceRefKey() string {
 	return so.TypeMeta.Kind + "/" + so.TypeMeta.APIVersion + "/" + so.ObjectMeta.Namespace + "/" + so.ObjectMeta.Name
 }
 
+// Fingerprint identifies a finding by the check, the object and the path of the comment. The fingerprint stays the
+// same when the manifests are changed in other ways, and can be used to track findings across runs.
+// The API version is not part of the fingerprint, so that migrating an object to a new API version keeps its findings.
+func (so *ScoredObject) Fingerprint(checkID, path string) string {
+	h := sha256.New()
+	for _, part := range []string{checkID, so.TypeMeta.Kind, so.ObjectMeta.Namespace, so.ObjectMeta.Name, path} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CommentFingerprints returns the fingerprints of the comments of a check, in the same order as the comments
+// A check can have multiple comments with the same path. If they have different summaries, the summary is also a part
+// of the fingerprint. Comments that are identical are numbered in order. The fingerprint of a comment does not depend
+// on its position, so adding or removing a comment does not change the fingerprints of the other comments.
+func (so *ScoredObject) CommentFingerprints(checkID string, comments []TestScoreComment) []string {
+	summaries := make(map[string]map[string]struct{})
+	for _, c := range comments {
+		if summaries[c.Path] == nil {
+			summaries[c.Path] = make(map[string]struct{})
+		}
+		summaries[c.Path][c.Summary] = struct{}{}
+	}
+
+	res := make([]string, 0, len(comments))
+	seen := make(map[string]int)
+	for _, c := range comments {
+		path := c.Path
+		if len(summaries[c.Path]) > 1 {
+			path += "#" + c.Summary
+		}
+		if n := seen[path]; n > 0 {
+			seen[path]++
+			path = fmt.Sprintf("%s#%d", path, n)
+		} else {
+			seen[path] = 1
+		}
+		res = append(res, so.Fingerprint(checkID, path))
+	}
+	return res
+}
+
 func (so *ScoredObject) HumanFriendlyRef() string {
 	s := so.ObjectMeta.Name
 	if so.ObjectMeta.Namespace != "" {
@@ -91,23 +143,40 @@ func (so *ScoredObject) HumanFriendlyRef() string {
 	return s
 }
 
+// CommentLocation returns the location of a comment, which is the line of the path of the comment if it's known,
+// and the location of the object otherwise
+func (so *ScoredObject) CommentLocation(comment TestScoreComment) ks.FileLocation {
+	location := so.FileLocation
+	if location.Lines != nil && comment.Path != "" {
+		if line, ok := location.Lines.Line(comment.Path); ok {
+			location.Line = line
+		}
+	}
+	return location
+}
+
 func (so *ScoredObject) Add(ts TestScore, check ks.Check, locationer ks.FileLocationer, annotations ...map[string]string) {
 	ts.Check = check
 	so.FileLocation = locationer.FileLocation()
 
 	var skip bool
 	if annotations != nil {
-		if len(annotations) == 1 && !so.isEnabled(check, annotations[0], nil) {
-			skip = true
+		var childAnnotations map[string]string
+		if len(annotations) == 2 {
+			childAnnotations = annotations[1]
 		}
-		if len(annotations) == 2 && !so.isEnabled(check, annotations[0], annotations[1]) {
+		if len(annotations) <= 2 && !so.isEnabled(check, annotations[0], childAnnotations) {
 			skip = true
+			ts.Ignored = so.isIgnored(check, annotations[0], childAnnotations)
 		}
 	}
 
 	// This test is ignored (via annotations), don't save the score
 	if skip {
 		ts.Skipped = true
+		if ts.Ignored {
+			ts.IgnoredComments = ts.Comments
+		}
 		ts.Comments = []TestScoreComment{{Summary: fmt.Sprintf("Skipped because %s is ignored", check.ID)}}
 	}
 
@@ -115,10 +184,19 @@ func (so *ScoredObject) Add(ts TestScore, check ks.Check, locationer ks.FileLoca
 }
 
 type TestScore struct {
-	Check    ks.Check
-	Grade    Grade
-	Skipped  bool
+	Check   ks.Check
+	Grade   Grade
+	Skipped bool
+
+	// Ignored is true if the check was skipped because of the kube-score/ignore annotation. Grade is the grade that
+	// the object would have had without the annotation.
+	Ignored bool
+
 	Comments []TestScoreComment
+
+	// IgnoredComments are the comments of an ignored check, from before they were replaced with the skip reason
+	// It is not a part of the v1 JSON output.
+	IgnoredComments []TestScoreComment `json:"-"`
 }
 
 type Grade int
@@ -150,6 +228,13 @@ type TestScoreComment struct {
 	DocumentationURL string
 }
 
+// LowerGrade sets the grade of the score to grade, unless the score already has a lower grade
+func (ts *TestScore) LowerGrade(grade Grade) {
+	if grade < ts.Grade {
+		ts.Grade = grade
+	}
+}
+
 func (ts *TestScore) AddComment(path, summary, description string) {
 	ts.Comments = append(ts.Comments, TestScoreComment{
 		Path:        path,
@@ -0,0 +1,43 @@
+package scorecard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCommentFingerprints(t *testing.T) {
+	t.Parallel()
+	so := &ScoredObject{
+		TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "a"},
+	}
+
+	before := so.CommentFingerprints("check", []TestScoreComment{
+		{Path: "a", Summary: "summary a"},
+		{Path: "b", Summary: "summary b"},
+		{Path: "b", Summary: "another summary b"},
+		{Path: "c", Summary: "summary c"},
+	})
+	assert.Equal(t, so.Fingerprint("check", "a"), before[0])
+	assert.Equal(t, so.Fingerprint("check", "b#summary b"), before[1])
+	assert.Equal(t, so.Fingerprint("check", "b#another summary b"), before[2])
+
+	// Fingerprints do not depend on the order of the comments, or on other comments
+	after := so.CommentFingerprints("check", []TestScoreComment{
+		{Path: "b", Summary: "new summary b"},
+		{Path: "b", Summary: "another summary b"},
+		{Path: "c", Summary: "summary c"},
+		{Path: "b", Summary: "summary b"},
+	})
+	assert.Equal(t, []string{before[2], before[3], before[1]}, after[1:])
+
+	// Identical comments are numbered, and the first one keeps the fingerprint of the path
+	identical := so.CommentFingerprints("check", []TestScoreComment{
+		{Path: "c", Summary: "summary c"},
+		{Path: "c", Summary: "summary c"},
+	})
+	assert.Equal(t, before[3], identical[0])
+	assert.Equal(t, so.Fingerprint("check", "c#1"), identical[1])
+}
@@ -0,0 +1,126 @@
+package scorecard
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Summary has the number of check results with each grade, and a score from 0 to 100 of them
+type Summary struct {
+	Critical int
+	Warning  int
+	OK       int
+	Skipped  int
+
+	// Score is the weighted average of the grades of all checks that are not skipped, where critical is 0 and
+	// AllOK is 100. The weight of a check is 1, unless it is configured in config.ScorePolicy. The score is 100 if no
+	// checks were run.
+	Score int
+
+	points float64
+	weight float64
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("Score: %d/100, %d critical, %d warning, %d ok, %d skipped", s.Score, s.Critical, s.Warning, s.OK, s.Skipped)
+}
+
+func (s *Summary) add(ts TestScore, weight float64) {
+	switch {
+	case ts.Skipped:
+		s.Skipped++
+		return
+	case ts.Grade <= GradeCritical:
+		s.Critical++
+	case ts.Grade <= GradeWarning:
+		s.Warning++
+	default:
+		s.OK++
+	}
+
+	s.points += weight * float64(ts.Grade-GradeCritical) / float64(GradeAllOK-GradeCritical)
+	s.weight += weight
+	s.updateScore()
+}
+
+func (s *Summary) merge(other Summary) {
+	s.Critical += other.Critical
+	s.Warning += other.Warning
+	s.OK += other.OK
+	s.Skipped += other.Skipped
+	s.points += other.points
+	s.weight += other.weight
+	s.updateScore()
+}
+
+func (s *Summary) updateScore() {
+	if s.weight <= 0 {
+		s.Score = 100
+		return
+	}
+	s.Score = int(math.Round(100 * s.points / s.weight))
+}
+
+func newSummary() Summary {
+	return Summary{Score: 100}
+}
+
+// Summary summarizes the checks of the object
+func (so *ScoredObject) Summary() Summary {
+	s := newSummary()
+	for _, ts := range so.Checks {
+		weight := 1.0
+		if w, ok := so.checkWeights[ts.Check.ID]; ok {
+			weight = w
+		}
+		s.add(ts, weight)
+	}
+	return s
+}
+
+// ScorecardSummary summarizes all checks, grouped by namespace and kind
+type ScorecardSummary struct {
+	Overall Summary
+
+	// Namespaces has the summary of the objects in each namespace. Objects without a namespace are summarized with
+	// the empty string as key.
+	Namespaces map[string]Summary
+	Kinds      map[string]Summary
+}
+
+func (s Scorecard) Summary() ScorecardSummary {
+	res := ScorecardSummary{
+		Overall:    newSummary(),
+		Namespaces: make(map[string]Summary),
+		Kinds:      make(map[string]Summary),
+	}
+
+	var keys []string
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		o := s[k]
+		summary := o.Summary()
+		res.Overall.merge(summary)
+
+		ns, ok := res.Namespaces[o.ObjectMeta.Namespace]
+		if !ok {
+			ns = newSummary()
+		}
+		ns.merge(summary)
+		res.Namespaces[o.ObjectMeta.Namespace] = ns
+
+		kind, ok := res.Kinds[o.TypeMeta.Kind]
+		if !ok {
+			kind = newSummary()
+		}
+		kind.merge(summary)
+		res.Kinds[o.TypeMeta.Kind] = kind
+	}
+
+	return res
+}
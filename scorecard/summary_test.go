@@ -0,0 +1,63 @@
+package scorecard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zegl/kube-score/config"
+	ks "github.com/zegl/kube-score/domain"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func addObject(s Scorecard, cnf *config.RunConfiguration, kind, namespace, name string, grades ...Grade) {
+	o := s.NewObject(metav1.TypeMeta{Kind: kind, APIVersion: "v1"}, metav1.ObjectMeta{Namespace: namespace, Name: name}, cnf)
+	for i, g := range grades {
+		ts := TestScore{Check: ks.Check{ID: []string{"a", "b", "c", "d"}[i]}, Grade: g}
+		if g == 0 {
+			ts.Skipped = true
+		}
+		o.Checks = append(o.Checks, ts)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	s := New()
+	addObject(s, nil, "Deployment", "foo", "a", GradeCritical, GradeAllOK)
+	addObject(s, nil, "Deployment", "bar", "b", GradeWarning, GradeAlmostOK, 0)
+	addObject(s, nil, "Service", "foo", "c", GradeAllOK)
+
+	summary := s.Summary()
+	assert.Equal(t, 1, summary.Overall.Critical)
+	assert.Equal(t, 1, summary.Overall.Warning)
+	assert.Equal(t, 3, summary.Overall.OK)
+	assert.Equal(t, 1, summary.Overall.Skipped)
+	// (0 + 1 + 4/9 + 6/9 + 1) / 5
+	assert.Equal(t, 62, summary.Overall.Score)
+
+	assert.Equal(t, 67, summary.Namespaces["foo"].Score)
+	assert.Equal(t, 56, summary.Namespaces["bar"].Score)
+	assert.Equal(t, 53, summary.Kinds["Deployment"].Score)
+	assert.Equal(t, 100, summary.Kinds["Service"].Score)
+
+	assert.Equal(t, 50, s["Deployment/v1/foo/a"].Summary().Score)
+	assert.Equal(t, "Score: 62/100, 1 critical, 1 warning, 3 ok, 1 skipped", summary.Overall.String())
+}
+
+func TestSummaryWeights(t *testing.T) {
+	s := New()
+	cnf := &config.RunConfiguration{ScorePolicy: config.ScorePolicy{CheckWeights: map[string]float64{"a": 3, "b": 0}}}
+	addObject(s, cnf, "Deployment", "foo", "a", GradeAllOK, GradeCritical, GradeCritical)
+
+	// (3 * 1 + 0 * 0 + 1 * 0) / 4
+	assert.Equal(t, 75, s.Summary().Overall.Score)
+}
+
+func TestSummaryEmpty(t *testing.T) {
+	s := New()
+	assert.Equal(t, 100, s.Summary().Overall.Score)
+
+	// Only skipped checks
+	addObject(s, nil, "Deployment", "foo", "a", 0)
+	assert.Equal(t, 100, s.Summary().Overall.Score)
+	assert.Equal(t, 1, s.Summary().Overall.Skipped)
+}